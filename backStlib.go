@@ -4,6 +4,7 @@
 package tinytime
 
 import (
+	"sync"
 	"time"
 
 	. "github.com/cdvelop/tinystring"
@@ -11,20 +12,51 @@ import (
 
 // NewTimeProvider returns the correct implementation based on the build environment.
 func NewTimeProvider() TimeProvider {
-	return &timeServer{}
+	return &timeServer{loc: time.UTC}
 }
 
+// NewTimeProviderInZone returns a TimeProvider bound to the given IANA time zone
+// (e.g. "America/Santiago"). An empty zone or "UTC" behaves like NewTimeProvider.
+// "Local" is rejected, since timeClient has no equivalent name for the system zone.
+func NewTimeProviderInZone(zone string) (TimeProvider, error) {
+	loc, err := time.LoadLocation(zone)
+	if err != nil || zone == "Local" {
+		return nil, Errf("invalid time zone: %s", zone)
+	}
+	return &timeServer{loc: loc}, nil
+}
+
+// monotonicBase anchors MonotonicNano: time.Since uses the monotonic reading of both values.
+var monotonicBase = time.Now()
+
 // timeServer implements TimeProvider for standard Go.
-type timeServer struct{}
+type timeServer struct {
+	loc *time.Location
+}
 
 func (ts *timeServer) UnixNano() int64 {
 	return time.Now().UTC().UnixNano()
 }
 
+func (ts *timeServer) MonotonicNano() int64 {
+	return int64(time.Since(monotonicBase))
+}
+
+func (ts *timeServer) Zone() string {
+	return ts.loc.String()
+}
+
+func (ts *timeServer) Offset(nano int64) int {
+	_, offset := time.Unix(0, nano).In(ts.loc).Zone()
+	return offset
+}
+
 func (ts *timeServer) FormatDate(value any) string {
 	switch v := value.(type) {
 	case int64:
-		return time.Unix(0, v).UTC().Format("2006-01-02")
+		return time.Unix(0, v).In(ts.loc).Format("2006-01-02")
+	case Date:
+		return v.String()
 	case string:
 		if _, err := time.Parse("2006-01-02", v); err == nil {
 			return v
@@ -36,11 +68,13 @@ func (ts *timeServer) FormatDate(value any) string {
 func (ts *timeServer) FormatTime(value any) string {
 	switch v := value.(type) {
 	case int64: // UnixNano
-		return time.Unix(0, v).UTC().Format("15:04:05")
+		return time.Unix(0, v).In(ts.loc).Format("15:04:05")
+	case TimeOfDay:
+		return v.String()
 	case int16: // Minutes since midnight
-		hours := v / 60
-		minutes := v % 60
-		return Fmt("%02d:%02d", hours, minutes)
+		return TimeOfDay(v).String()
+	case int32: // Seconds since midnight
+		return formatTimeSeconds(v)
 	case string:
 		if Count(v, ":") >= 1 {
 			return v
@@ -52,7 +86,7 @@ func (ts *timeServer) FormatTime(value any) string {
 func (ts *timeServer) FormatDateTime(value any) string {
 	switch v := value.(type) {
 	case int64:
-		return time.Unix(0, v).UTC().Format("2006-01-02 15:04:05")
+		return time.Unix(0, v).In(ts.loc).Format("2006-01-02 15:04:05")
 	case string:
 		if _, err := time.Parse("2006-01-02 15:04:05", v); err == nil {
 			return v
@@ -64,7 +98,7 @@ func (ts *timeServer) FormatDateTime(value any) string {
 func (ts *timeServer) FormatDateTimeShort(value any) string {
 	switch v := value.(type) {
 	case int64:
-		return time.Unix(0, v).UTC().Format("2006-01-02 15:04")
+		return time.Unix(0, v).In(ts.loc).Format("2006-01-02 15:04")
 	case string:
 		if _, err := time.Parse("2006-01-02 15:04", v); err == nil {
 			return v
@@ -73,34 +107,49 @@ func (ts *timeServer) FormatDateTimeShort(value any) string {
 	return ""
 }
 
+func (ts *timeServer) Format(value any, layout string) string {
+	return formatLayout(value, layout, ts.Offset)
+}
+
+func (ts *timeServer) FormatRFC3339(nano int64, offset int) string {
+	return formatRFC3339(nano, offset)
+}
+
+func (ts *timeServer) ParseRFC3339(s string) (int64, error) {
+	return parseRFC3339(s)
+}
+
+func (ts *timeServer) FormatRelative(nano int64) string {
+	return formatRelative(nano, ts.UnixNano())
+}
+
+func (ts *timeServer) FormatRelativeTo(nano, base int64) string {
+	return formatRelative(nano, base)
+}
+
 func (ts *timeServer) ParseDate(dateStr string) (int64, error) {
-	t, err := time.ParseInLocation("2006-01-02", dateStr, time.UTC)
-	if err != nil {
-		return 0, err
-	}
-	return t.UnixNano(), nil
+	return parseDateTime(dateStr, "", ts.Offset)
 }
 
-func (ts *timeServer) ParseTime(timeStr string) (int16, error) {
+func (ts *timeServer) Parse(input string, layouts ...string) (int64, error) {
+	return parseLayouts(input, layouts, ts.Offset)
+}
+
+func (ts *timeServer) ParseTime(timeStr string) (TimeOfDay, error) {
 	return parseTime(timeStr)
 }
 
-func (ts *timeServer) ParseDateTime(dateStr, timeStr string) (int64, error) {
-	layout := "2006-01-02 15:04:05"
-	if len(timeStr) == 5 {
-		layout = "2006-01-02 15:04"
-	}
+func (ts *timeServer) ParseTimeSeconds(timeStr string) (int32, error) {
+	return parseTimeSeconds(timeStr)
+}
 
-	t, err := time.ParseInLocation(layout, dateStr+" "+timeStr, time.UTC)
-	if err != nil {
-		return 0, err
-	}
-	return t.UnixNano(), nil
+func (ts *timeServer) ParseDateTime(dateStr, timeStr string) (int64, error) {
+	return parseDateTime(dateStr, timeStr, ts.Offset)
 }
 
 func (ts *timeServer) IsToday(nano int64) bool {
-	t := time.Unix(0, nano).UTC()
-	now := time.Now().UTC()
+	t := time.Unix(0, nano).In(ts.loc)
+	now := time.Now().In(ts.loc)
 	return t.Year() == now.Year() && t.YearDay() == now.YearDay()
 }
 
@@ -116,16 +165,216 @@ func (ts *timeServer) DaysBetween(nano1, nano2 int64) int {
 	return daysBetween(nano1, nano2)
 }
 
+func (ts *timeServer) CalendarDaysBetween(nano1, nano2 int64) int {
+	return calendarDaysBetween(nano1, nano2, ts.Offset)
+}
+
+func (ts *timeServer) DiffComponents(nano1, nano2 int64) DateDiff {
+	return diffComponents(nano1, nano2, ts.Offset)
+}
+
+func (ts *timeServer) AddDays(nano int64, n int) int64 {
+	return addDate(nano, 0, 0, n, ts.Offset)
+}
+
+func (ts *timeServer) AddMonths(nano int64, n int) int64 {
+	return addDate(nano, 0, n, 0, ts.Offset)
+}
+
+func (ts *timeServer) AddYears(nano int64, n int) int64 {
+	return addDate(nano, n, 0, 0, ts.Offset)
+}
+
+func (ts *timeServer) StartOfDay(nano int64) int64 {
+	return startOf(nano, periodDay, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) EndOfDay(nano int64) int64 {
+	return endOf(nano, periodDay, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) StartOfWeek(nano int64, firstDay Weekday) int64 {
+	return startOf(nano, periodWeek, firstDay, ts.Offset)
+}
+
+func (ts *timeServer) EndOfWeek(nano int64, firstDay Weekday) int64 {
+	return endOf(nano, periodWeek, firstDay, ts.Offset)
+}
+
+func (ts *timeServer) StartOfMonth(nano int64) int64 {
+	return startOf(nano, periodMonth, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) EndOfMonth(nano int64) int64 {
+	return endOf(nano, periodMonth, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) StartOfQuarter(nano int64) int64 {
+	return startOf(nano, periodQuarter, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) EndOfQuarter(nano int64) int64 {
+	return endOf(nano, periodQuarter, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) StartOfYear(nano int64) int64 {
+	return startOf(nano, periodYear, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) EndOfYear(nano int64) int64 {
+	return endOf(nano, periodYear, Sunday, ts.Offset)
+}
+
+func (ts *timeServer) Weekday(nano int64) Weekday {
+	return weekdayOf(nano, ts.Offset)
+}
+
+func (ts *timeServer) ISOWeek(nano int64) (year, week int) {
+	return isoWeek(nano, ts.Offset)
+}
+
+func (ts *timeServer) Quarter(nano int64) int {
+	return quarter(nano, ts.Offset)
+}
+
+func (ts *timeServer) DayOfYear(nano int64) int {
+	return dayOfYear(nano, ts.Offset)
+}
+
+func (ts *timeServer) FormatISOWeekDate(nano int64) string {
+	return formatISOWeekDate(nano, ts.Offset)
+}
+
+func (ts *timeServer) ParseISOWeekDate(s string) (int64, error) {
+	return parseISOWeekDate(s, ts.Offset)
+}
+
 // timerWrapper wraps time.Timer to implement Timer interface
 type timerWrapper struct {
-	timer *time.Timer
+	mu      sync.Mutex
+	timer   *time.Timer
+	active  bool
+	pending int // callbacks already dispatched for a deadline that was later stopped or reset
+	f       func()
+}
+
+// cancel disarms the timer. Caller must hold tw.mu.
+func (tw *timerWrapper) cancel() bool {
+	wasActive := tw.active
+	if !tw.timer.Stop() && wasActive {
+		// time.Timer already started fire() in its own goroutine; make it a no-op
+		tw.pending++
+	}
+	tw.active = false
+	return wasActive
+}
+
+func (tw *timerWrapper) fire() {
+	tw.mu.Lock()
+	if tw.pending > 0 {
+		tw.pending--
+		tw.mu.Unlock()
+		return
+	}
+	if !tw.active {
+		tw.mu.Unlock()
+		return
+	}
+	tw.active = false
+	tw.mu.Unlock()
+
+	if tw.f != nil {
+		tw.f()
+	}
 }
 
 func (tw *timerWrapper) Stop() bool {
-	return tw.timer.Stop()
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.cancel()
+}
+
+func (tw *timerWrapper) Reset(milliseconds int) bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	wasActive := tw.cancel()
+	tw.active = true
+	tw.timer.Reset(time.Duration(milliseconds) * time.Millisecond)
+	return wasActive
+}
+
+func (tw *timerWrapper) Active() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	return tw.active
 }
 
 func (ts *timeServer) AfterFunc(milliseconds int, f func()) Timer {
-	t := time.AfterFunc(time.Duration(milliseconds)*time.Millisecond, f)
-	return &timerWrapper{timer: t}
+	tw := &timerWrapper{active: true, f: f}
+	tw.mu.Lock() // fire() waits until tw.timer is assigned
+	tw.timer = time.AfterFunc(time.Duration(milliseconds)*time.Millisecond, tw.fire)
+	tw.mu.Unlock()
+	return tw
+}
+
+// tickerWrapper wraps time.Ticker to implement Ticker interface
+type tickerWrapper struct {
+	mu     sync.Mutex
+	ticker *time.Ticker
+	done   chan struct{} // nil while stopped
+	f      func()
+}
+
+// start launches a new ticker goroutine. Caller must hold tw.mu.
+func (tw *tickerWrapper) start(milliseconds int) {
+	tw.ticker = time.NewTicker(time.Duration(tickerInterval(milliseconds)) * time.Millisecond)
+	tw.done = make(chan struct{})
+	go tw.run(tw.ticker, tw.done)
+}
+
+func (tw *tickerWrapper) run(ticker *time.Ticker, done chan struct{}) {
+	for {
+		select {
+		case <-ticker.C:
+			select {
+			case <-done: // stopped while the tick was pending
+				return
+			default:
+			}
+			if tw.f != nil {
+				tw.f()
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+func (tw *tickerWrapper) Stop() bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.done == nil {
+		return false
+	}
+	tw.ticker.Stop()
+	close(tw.done)
+	tw.done = nil
+	return true
+}
+
+func (tw *tickerWrapper) Reset(milliseconds int) bool {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.done == nil {
+		tw.start(milliseconds)
+		return false
+	}
+	tw.ticker.Reset(time.Duration(tickerInterval(milliseconds)) * time.Millisecond)
+	return true
+}
+
+func (ts *timeServer) Every(milliseconds int, f func()) Ticker {
+	tw := &tickerWrapper{f: f}
+	tw.start(milliseconds)
+	return tw
 }
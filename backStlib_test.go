@@ -5,6 +5,7 @@ package tinytime_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cdvelop/tinytime"
 )
@@ -21,9 +22,77 @@ func TestAllShared(t *testing.T) {
 	t.Run("FormatDateTimeShort", func(t *testing.T) { FormatDateTimeShortShared(t, tp) })
 	t.Run("ParseDate", func(t *testing.T) { ParseDateShared(t, tp) })
 	t.Run("ParseTime", func(t *testing.T) { ParseTimeShared(t, tp) })
+	t.Run("ParseTimeSeconds", func(t *testing.T) { ParseTimeSecondsShared(t, tp) })
 	t.Run("ParseDateTime", func(t *testing.T) { ParseDateTimeShared(t, tp) })
 	t.Run("IsToday", func(t *testing.T) { IsTodayShared(t, tp) })
 	t.Run("IsPast", func(t *testing.T) { IsPastShared(t, tp) })
 	t.Run("IsFuture", func(t *testing.T) { IsFutureShared(t, tp) })
 	t.Run("DaysBetween", func(t *testing.T) { DaysBetweenShared(t, tp) })
+	t.Run("Format", func(t *testing.T) { FormatShared(t, tp) })
+	t.Run("Parse", func(t *testing.T) { ParseShared(t, tp) })
+	t.Run("RFC3339", func(t *testing.T) { RFC3339Shared(t, tp) })
+	t.Run("FormatRelative", func(t *testing.T) { FormatRelativeShared(t, tp) })
+	t.Run("AddDate", func(t *testing.T) { AddDateShared(t, tp) })
+	t.Run("PeriodBounds", func(t *testing.T) { PeriodBoundsShared(t, tp) })
+	t.Run("CalendarDiff", func(t *testing.T) { CalendarDiffShared(t, tp) })
+	t.Run("ISOWeek", func(t *testing.T) { ISOWeekShared(t, tp) })
+}
+
+// Format with Go reference layouts must match the time package exactly.
+func TestFormat_MatchesTimePackage(t *testing.T) {
+	layouts := []string{
+		time.RFC3339, time.RFC3339Nano, time.Kitchen, time.ANSIC, time.DateTime,
+		"Monday, 02-Jan-06 15:04:05", "2006-01-02T15:04:05.000000-0700", "3:4:5 pm", "2006 002 _2",
+	}
+	zones := []string{"UTC", "America/Santiago", "Asia/Kolkata"}
+	instants := []int64{0, 1705307400123456789, 1721044800000000000, -86400000000001, 4102444799999999999}
+
+	for _, zone := range zones {
+		tp, err := tinytime.NewTimeProviderInZone(zone)
+		if err != nil {
+			t.Fatalf("NewTimeProviderInZone(%s) failed: %v", zone, err)
+		}
+		loc, _ := time.LoadLocation(zone)
+		for _, layout := range layouts {
+			for _, nano := range instants {
+				want := time.Unix(0, nano).In(loc).Format(layout)
+				if got := tp.Format(nano, layout); got != want {
+					t.Errorf("[%s] Format(%d, %q) = %q; want %q", zone, nano, layout, got, want)
+				}
+			}
+		}
+	}
+}
+
+// FormatRFC3339 must match time.RFC3339Nano exactly.
+func TestFormatRFC3339_MatchesTimePackage(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	for _, nano := range []int64{0, 1705307400123456789, 1705307400120000000, -86400000000001} {
+		for _, offset := range []int{0, -3 * 3600, 5*3600 + 1800} {
+			want := time.Unix(0, nano).In(time.FixedZone("", offset)).Format(time.RFC3339Nano)
+			if got := tp.FormatRFC3339(nano, offset); got != want {
+				t.Errorf("FormatRFC3339(%d, %d) = %q; want %q", nano, offset, got, want)
+			}
+		}
+	}
+}
+
+// Weekday, ISOWeek and DayOfYear must match the time package for every day over several years.
+func TestISOWeek_MatchesTimePackage(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	start := time.Date(1999, 12, 20, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 12*366; i++ {
+		day := start.AddDate(0, 0, i)
+		nano := day.UnixNano()
+		wantYear, wantWeek := day.ISOWeek()
+		if year, week := tp.ISOWeek(nano); year != wantYear || week != wantWeek {
+			t.Fatalf("ISOWeek(%s) = %d, %d; want %d, %d", day.Format(time.DateOnly), year, week, wantYear, wantWeek)
+		}
+		if got := tp.Weekday(nano); int(got) != int(day.Weekday()) {
+			t.Fatalf("Weekday(%s) = %s; want %s", day.Format(time.DateOnly), got, day.Weekday())
+		}
+		if got := tp.DayOfYear(nano); got != day.YearDay() {
+			t.Fatalf("DayOfYear(%s) = %d; want %d", day.Format(time.DateOnly), got, day.YearDay())
+		}
+	}
 }
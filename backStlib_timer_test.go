@@ -3,6 +3,7 @@
 package tinytime_test
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -41,3 +42,171 @@ func TestAfterFunc_Stop(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 	AfterFuncStopVerify(t, executed)
 }
+
+func TestEvery(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	var mu sync.Mutex
+	count := 0
+
+	ticker := tp.Every(20, func() {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	time.Sleep(110 * time.Millisecond)
+	if !ticker.Stop() {
+		t.Error("Stop() should return true for active ticker")
+	}
+
+	mu.Lock()
+	ticks := count
+	mu.Unlock()
+	if ticks < 2 {
+		t.Errorf("ticker fired %d times; want at least 2", ticks)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	mu.Lock()
+	if count != ticks {
+		t.Errorf("ticker fired after Stop(): %d -> %d", ticks, count)
+	}
+	mu.Unlock()
+
+	if ticker.Stop() {
+		t.Error("Stop() should return false for already-stopped ticker")
+	}
+	t.Log("Every test passed")
+}
+
+func TestEvery_Reset(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	var mu sync.Mutex
+	count := 0
+
+	ticker := tp.Every(1000, func() {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	if !ticker.Reset(20) {
+		t.Error("Reset() should return true for active ticker")
+	}
+	time.Sleep(110 * time.Millisecond)
+	ticker.Stop()
+
+	mu.Lock()
+	if count < 2 {
+		t.Errorf("ticker fired %d times after Reset(20); want at least 2", count)
+	}
+	mu.Unlock()
+
+	// Reset on a stopped ticker restarts it
+	if ticker.Reset(1000) {
+		t.Error("Reset() should return false for stopped ticker")
+	}
+	if !ticker.Stop() {
+		t.Error("Stop() should return true after Reset restarted the ticker")
+	}
+	t.Log("Every Reset test passed")
+}
+
+func TestAfterFunc_ResetActive(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	var mu sync.Mutex
+	count := 0
+
+	timer := tp.AfterFunc(50, func() {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	if !timer.Active() {
+		t.Error("Active() should return true for armed timer")
+	}
+
+	// Debounce: keep pushing the deadline back
+	for i := 0; i < 3; i++ {
+		time.Sleep(20 * time.Millisecond)
+		if !timer.Reset(50) {
+			t.Error("Reset() should return true for active timer")
+		}
+	}
+
+	mu.Lock()
+	if count != 0 {
+		t.Errorf("callback fired %d times while being reset; want 0", count)
+	}
+	mu.Unlock()
+
+	time.Sleep(100 * time.Millisecond)
+	mu.Lock()
+	if count != 1 {
+		t.Errorf("callback fired %d times; want 1", count)
+	}
+	mu.Unlock()
+
+	if timer.Active() {
+		t.Error("Active() should return false after timer fired")
+	}
+
+	// Reset re-arms a fired timer
+	if timer.Reset(20) {
+		t.Error("Reset() should return false for fired timer")
+	}
+	time.Sleep(60 * time.Millisecond)
+	mu.Lock()
+	if count != 2 {
+		t.Errorf("callback fired %d times after re-arm; want 2", count)
+	}
+	mu.Unlock()
+	t.Log("AfterFunc Reset/Active test passed")
+}
+
+func TestAfterFunc_NilCallback(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	timer := tp.AfterFunc(10, nil) // must not panic when firing
+	time.Sleep(40 * time.Millisecond)
+
+	if timer.Active() {
+		t.Error("Active() should return false after timer fired")
+	}
+}
+
+func TestDebounce_RealTimers(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	var mu sync.Mutex
+	count := 0
+	d := tinytime.Debounce(tp, 30, func() {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+
+	for range 5 {
+		d.Call()
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != 1 {
+		t.Errorf("debounced function ran %d times; want 1", count)
+	}
+}
+
+func TestStopwatch_RealClock(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	sw := tinytime.NewStopwatch(tp)
+
+	sw.Start()
+	time.Sleep(20 * time.Millisecond)
+	elapsed := sw.Stop()
+	if elapsed < int64(20*time.Millisecond) || elapsed > int64(time.Second) {
+		t.Errorf("Stopwatch measured %d ns for a 20ms sleep", elapsed)
+	}
+}
@@ -0,0 +1,265 @@
+package tinytime
+
+import (
+	"slices"
+	"sync"
+)
+
+// holidayKind selects how a Holiday finds its date in a given year.
+type holidayKind uint8
+
+const (
+	holidayFixed  holidayKind = iota + 1 // same month and day every year
+	holidayNth                           // Nth weekday of a month
+	holidayEaster                        // days relative to Easter Sunday
+	holidayOneOff                        // a single date
+)
+
+// Holiday is a rule that gives the date of a holiday in each year.
+// Create it with FixedHoliday, NthWeekdayHoliday, EasterHoliday or DateHoliday.
+type Holiday struct {
+	kind    holidayKind
+	month   int
+	day     int // day of the month, N of the weekday, or days from Easter
+	weekday Weekday
+	date    Date
+}
+
+// FixedHoliday is a holiday on the same month (1-12) and day every year, e.g. FixedHoliday(12, 25).
+// A February 29 holiday only occurs in leap years.
+func FixedHoliday(month, day int) Holiday {
+	return Holiday{kind: holidayFixed, month: month, day: day}
+}
+
+// NthWeekdayHoliday is a holiday on the Nth weekday of a month, counting from the end when n is
+// negative: NthWeekdayHoliday(11, 4, Thursday) is the 4th Thursday of November and
+// NthWeekdayHoliday(5, -1, Monday) the last Monday of May.
+func NthWeekdayHoliday(month, n int, day Weekday) Holiday {
+	return Holiday{kind: holidayNth, month: month, day: n, weekday: day}
+}
+
+// EasterHoliday is a holiday the given days after (or before, when negative) Western Easter Sunday:
+// EasterHoliday(-2) is Good Friday and EasterHoliday(1) Easter Monday.
+func EasterHoliday(days int) Holiday {
+	return Holiday{kind: holidayEaster, day: days}
+}
+
+// DateHoliday is a holiday on a single date, e.g. an election day.
+func DateHoliday(d Date) Holiday {
+	return Holiday{kind: holidayOneOff, date: d}
+}
+
+// in returns the day count of the holiday in the given year, or false if it does not occur.
+// Easter-relative holidays may fall in the previous or next calendar year.
+func (h Holiday) in(year int) (int64, bool) {
+	switch h.kind {
+	case holidayFixed:
+		if h.month < 1 || h.month > 12 || h.day < 1 || h.day > daysInMonth(year, h.month) {
+			return 0, false
+		}
+		return daysFromCivil(year, h.month, h.day), true
+	case holidayNth:
+		if h.month < 1 || h.month > 12 || h.day == 0 || h.weekday < Sunday || h.weekday > Saturday {
+			return 0, false
+		}
+		var d int64
+		if h.day > 0 {
+			first := daysFromCivil(year, h.month, 1)
+			d = first + int64((h.weekday-weekdayFromDays(first)+7)%7) + int64(h.day-1)*7
+		} else {
+			last := daysFromCivil(year, h.month+1, 1) - 1
+			d = last - int64((weekdayFromDays(last)-h.weekday+7)%7) + int64(h.day+1)*7
+		}
+		if _, month, _ := civilFromDays(d); month != h.month {
+			return 0, false // there is no 5th Monday this month
+		}
+		return d, true
+	case holidayEaster:
+		return easterDays(year) + int64(h.day), true
+	case holidayOneOff:
+		return int64(h.date), true
+	}
+	return 0, false
+}
+
+// easterDays returns the day count of Western (Gregorian) Easter Sunday with the anonymous
+// Gregorian algorithm (Meeus/Jones/Butcher).
+func easterDays(year int) int64 {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return daysFromCivil(year, month, day)
+}
+
+// maxNonBusinessDays bounds the search for a business day, so a calendar whose weekend covers
+// the whole week returns 0 instead of looping forever.
+const maxNonBusinessDays = 366
+
+// BusinessCalendar counts business days: days that are neither weekend days nor holidays,
+// evaluated on the local dates of the provider's zone. It is safe for concurrent use.
+type BusinessCalendar struct {
+	offset   func(nano int64) int
+	weekend  [7]bool
+	holidays []Holiday
+
+	mu    sync.Mutex
+	years map[int][]int64 // sorted holiday days of each year already looked up
+}
+
+// NewBusinessCalendar returns a calendar in the provider's zone whose weekly days off are weekend
+// (e.g. Saturday and Sunday) and whose holidays follow the given rules.
+func NewBusinessCalendar(tp TimeProvider, weekend []Weekday, holidays ...Holiday) *BusinessCalendar {
+	c := &BusinessCalendar{offset: tp.Offset, holidays: holidays, years: make(map[int][]int64)}
+	for _, d := range weekend {
+		if d >= Sunday && d <= Saturday {
+			c.weekend[d] = true
+		}
+	}
+	return c
+}
+
+// holidaysIn returns the sorted days of the holidays falling in the given calendar year.
+func (c *BusinessCalendar) holidaysIn(year int) []int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if days, ok := c.years[year]; ok {
+		return days
+	}
+	first, next := daysFromCivil(year, 1, 1), daysFromCivil(year+1, 1, 1)
+	days := []int64{}
+	for _, h := range c.holidays {
+		for y := year - 1; y <= year+1; y++ {
+			if d, ok := h.in(y); ok && d >= first && d < next {
+				days = append(days, d)
+			}
+		}
+	}
+	slices.Sort(days)
+	days = slices.Compact(days)
+	c.years[year] = days
+	return days
+}
+
+// isHoliday reports whether a day count is a holiday.
+func (c *BusinessCalendar) isHoliday(days int64) bool {
+	year, _, _ := civilFromDays(days)
+	_, found := slices.BinarySearch(c.holidaysIn(year), days)
+	return found
+}
+
+// isBusinessDay reports whether a day count is neither a weekend day nor a holiday.
+func (c *BusinessCalendar) isBusinessDay(days int64) bool {
+	return !c.weekend[weekdayFromDays(days)] && !c.isHoliday(days)
+}
+
+// localDays splits nano into its local day count and wall-clock time.
+func (c *BusinessCalendar) localDays(nano int64) (days, nanoOfDay int64) {
+	return splitNano(nano + int64(c.offset(nano))*1000000000)
+}
+
+// IsHoliday reports whether the local date of nano is a holiday.
+func (c *BusinessCalendar) IsHoliday(nano int64) bool {
+	days, _ := c.localDays(nano)
+	return c.isHoliday(days)
+}
+
+// IsBusinessDay reports whether the local date of nano is neither a weekend day nor a holiday.
+func (c *BusinessCalendar) IsBusinessDay(nano int64) bool {
+	days, _ := c.localDays(nano)
+	return c.isBusinessDay(days)
+}
+
+// Holidays returns the dates of the holidays in the given year, sorted and without duplicates.
+func (c *BusinessCalendar) Holidays(year int) []Date {
+	days := c.holidaysIn(year)
+	dates := make([]Date, len(days))
+	for i, d := range days {
+		dates[i] = Date(d)
+	}
+	return dates
+}
+
+// NextBusinessDay returns the start (local midnight) of the first business day after the date of nano,
+// or 0 if there is none within a year.
+func (c *BusinessCalendar) NextBusinessDay(nano int64) int64 {
+	days, _ := c.localDays(nano)
+	for i := 1; i <= maxNonBusinessDays; i++ {
+		if c.isBusinessDay(days + int64(i)) {
+			return utcFromLocal((days+int64(i))*nanosPerDay, c.offset)
+		}
+	}
+	return 0
+}
+
+// AddBusinessDays moves nano forward by n business days (backward when n is negative), keeping its
+// wall-clock time: Friday 10:00 + 1 is Monday 10:00 with a Saturday-Sunday weekend. The date of nano
+// itself does not need to be a business day (Saturday + 1 is Monday). n == 0 returns nano unchanged.
+// Returns 0 if the calendar has no business day within a year of any step.
+func (c *BusinessCalendar) AddBusinessDays(nano int64, n int) int64 {
+	if n == 0 {
+		return nano
+	}
+	days, nanoOfDay := c.localDays(nano)
+	step := int64(1)
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for ; n > 0; n-- {
+		skipped := 0
+		for days += step; !c.isBusinessDay(days); days += step {
+			if skipped++; skipped >= maxNonBusinessDays {
+				return 0
+			}
+		}
+	}
+	return utcFromLocal(days*nanosPerDay+nanoOfDay, c.offset)
+}
+
+// BusinessDaysBetween counts the business days after the date of nano1 up to and including the date of
+// nano2, so Monday to Friday of the same week is 4 and BusinessDaysBetween(a, AddBusinessDays(a, n)) is n
+// for a business day a. The result is negative when nano2 is on an earlier date.
+func (c *BusinessCalendar) BusinessDaysBetween(nano1, nano2 int64) int {
+	from, _ := c.localDays(nano1)
+	to, _ := c.localDays(nano2)
+	if to < from {
+		return -c.countBusinessDays(to, from)
+	}
+	return c.countBusinessDays(from, to)
+}
+
+// countBusinessDays counts the business days in (from, to]: whole weeks at once, then the remaining
+// days one by one, minus the holidays that fall on working weekdays.
+func (c *BusinessCalendar) countBusinessDays(from, to int64) int {
+	workdays := 0
+	for _, off := range c.weekend {
+		if !off {
+			workdays++
+		}
+	}
+	span := to - from
+	count := int(span/7) * workdays
+	for d := to - span%7 + 1; d <= to; d++ {
+		if !c.weekend[weekdayFromDays(d)] {
+			count++
+		}
+	}
+
+	fromYear, _, _ := civilFromDays(from + 1)
+	toYear, _, _ := civilFromDays(to)
+	for year := fromYear; year <= toYear; year++ {
+		for _, d := range c.holidaysIn(year) {
+			if d > from && d <= to && !c.weekend[weekdayFromDays(d)] {
+				count--
+			}
+		}
+	}
+	return count
+}
@@ -0,0 +1,164 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+var weekend = []tinytime.Weekday{tinytime.Saturday, tinytime.Sunday}
+
+func TestHoliday_Rules(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	tests := []struct {
+		name    string
+		holiday tinytime.Holiday
+		year    int
+		want    string // "" when the holiday does not occur that year
+	}{
+		{"Christmas", tinytime.FixedHoliday(12, 25), 2024, "2024-12-25"},
+		{"Leap day", tinytime.FixedHoliday(2, 29), 2024, "2024-02-29"},
+		{"Leap day", tinytime.FixedHoliday(2, 29), 2025, ""},
+		{"Thanksgiving", tinytime.NthWeekdayHoliday(11, 4, tinytime.Thursday), 2024, "2024-11-28"},
+		{"Memorial Day", tinytime.NthWeekdayHoliday(5, -1, tinytime.Monday), 2024, "2024-05-27"},
+		{"Labor Day", tinytime.NthWeekdayHoliday(9, 1, tinytime.Monday), 2025, "2025-09-01"},
+		{"5th Monday", tinytime.NthWeekdayHoliday(2, 5, tinytime.Monday), 2025, ""},
+		{"Easter", tinytime.EasterHoliday(0), 2024, "2024-03-31"},
+		{"Easter", tinytime.EasterHoliday(0), 2025, "2025-04-20"},
+		{"Easter", tinytime.EasterHoliday(0), 2000, "2000-04-23"},
+		{"Easter", tinytime.EasterHoliday(0), 2038, "2038-04-25"},
+		{"Easter", tinytime.EasterHoliday(0), 2285, "2285-03-22"},
+		{"Good Friday", tinytime.EasterHoliday(-2), 2024, "2024-03-29"},
+		{"Whit Monday", tinytime.EasterHoliday(50), 2024, "2024-05-20"},
+		{"Election", tinytime.DateHoliday(mustDate(t, "2024-10-27")), 2024, "2024-10-27"},
+		{"Election", tinytime.DateHoliday(mustDate(t, "2024-10-27")), 2025, ""},
+		{"Invalid month", tinytime.FixedHoliday(13, 1), 2024, ""},
+	}
+
+	for _, tt := range tests {
+		cal := tinytime.NewBusinessCalendar(tp, weekend, tt.holiday)
+		var got string
+		if dates := cal.Holidays(tt.year); len(dates) > 0 {
+			got = dates[0].String()
+		}
+		if got != tt.want {
+			t.Errorf("%s in %d = %q; want %q", tt.name, tt.year, got, tt.want)
+		}
+	}
+
+	// Holidays are sorted and deduplicated
+	cal := tinytime.NewBusinessCalendar(tp, weekend,
+		tinytime.FixedHoliday(12, 25), tinytime.FixedHoliday(1, 1), tinytime.EasterHoliday(-2), tinytime.FixedHoliday(3, 29))
+	var got []string
+	for _, d := range cal.Holidays(2024) {
+		got = append(got, d.String())
+	}
+	checkOccurrences(t, "Holidays(2024)", got, []string{"2024-01-01", "2024-03-29", "2024-12-25"})
+}
+
+func mustDate(t *testing.T, s string) tinytime.Date {
+	d, err := tinytime.ParseCivilDate(s)
+	if err != nil {
+		t.Fatalf("ParseCivilDate(%s) failed: %v", s, err)
+	}
+	return d
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	cal := tinytime.NewBusinessCalendar(tp, weekend,
+		tinytime.FixedHoliday(1, 1), tinytime.FixedHoliday(12, 25), tinytime.EasterHoliday(-2))
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	for date, want := range map[string]bool{
+		"2024-03-28": true,  // Thursday
+		"2024-03-29": false, // Good Friday
+		"2024-03-30": false, // Saturday
+		"2024-12-25": false, // Christmas
+		"2024-12-26": true,
+	} {
+		if got := cal.IsBusinessDay(parse(date)); got != want {
+			t.Errorf("IsBusinessDay(%s) = %v; want %v", date, got, want)
+		}
+	}
+	if !cal.IsHoliday(parse("2024-12-25 18:00")) || cal.IsHoliday(parse("2024-12-28")) {
+		t.Error("IsHoliday should only report holidays, not weekends")
+	}
+
+	adds := []struct {
+		start string
+		n     int
+		want  string
+	}{
+		{"2024-03-28 10:00", 1, "2024-04-01 10:00"},  // over Good Friday and the weekend
+		{"2024-03-28 10:00", 5, "2024-04-05 10:00"},  // Thursday + 5 business days
+		{"2024-03-30 10:00", 1, "2024-04-01 10:00"},  // from a Saturday
+		{"2024-04-01 10:00", -1, "2024-03-28 10:00"}, // backwards over Good Friday
+		{"2024-12-20 09:00", 5, "2024-12-30 09:00"},  // over Christmas
+		{"2024-12-31 09:00", 1, "2025-01-02 09:00"},  // over New Year
+		{"2024-03-30 10:00", 0, "2024-03-30 10:00"},
+	}
+	for _, tt := range adds {
+		if got := tp.FormatDateTimeShort(cal.AddBusinessDays(parse(tt.start), tt.n)); got != tt.want {
+			t.Errorf("AddBusinessDays(%s, %d) = %s; want %s", tt.start, tt.n, got, tt.want)
+		}
+	}
+
+	if got := tp.FormatDateTime(cal.NextBusinessDay(parse("2024-03-28 17:30"))); got != "2024-04-01 00:00:00" {
+		t.Errorf("NextBusinessDay(Thursday before Good Friday) = %s; want 2024-04-01 00:00:00", got)
+	}
+
+	between := []struct {
+		from, to string
+		want     int
+	}{
+		{"2024-03-25", "2024-03-29", 3}, // Monday to Good Friday
+		{"2024-03-25 08:00", "2024-03-25 18:00", 0},
+		{"2024-03-25", "2024-04-01", 4},
+		{"2024-04-01", "2024-03-25", -4},
+		{"2024-01-01", "2025-01-01", 261 - 2}, // weekdays after Jan 1 up to the New Year holiday, minus Good Friday and Christmas
+	}
+	for _, tt := range between {
+		if got := cal.BusinessDaysBetween(parse(tt.from), parse(tt.to)); got != tt.want {
+			t.Errorf("BusinessDaysBetween(%s, %s) = %d; want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// BusinessDaysBetween inverts AddBusinessDays from every business day
+	start := parse("2024-12-02 09:00")
+	for day := 0; day < 60; day++ {
+		from := tp.AddDays(start, day)
+		if !cal.IsBusinessDay(from) {
+			continue
+		}
+		for _, n := range []int{1, 3, 10, 25, -4} {
+			if got := cal.BusinessDaysBetween(from, cal.AddBusinessDays(from, n)); got != n {
+				t.Fatalf("BusinessDaysBetween(%s, AddBusinessDays(%d)) = %d", tp.FormatDate(from), n, got)
+			}
+		}
+	}
+}
+
+func TestBusinessCalendar_NoBusinessDays(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	all := []tinytime.Weekday{tinytime.Sunday, tinytime.Monday, tinytime.Tuesday, tinytime.Wednesday,
+		tinytime.Thursday, tinytime.Friday, tinytime.Saturday}
+	cal := tinytime.NewBusinessCalendar(tp, all)
+
+	if got := cal.AddBusinessDays(1705307400000000000, 1); got != 0 {
+		t.Errorf("AddBusinessDays without business days = %d; want 0", got)
+	}
+	if got := cal.NextBusinessDay(1705307400000000000); got != 0 {
+		t.Errorf("NextBusinessDay without business days = %d; want 0", got)
+	}
+	if got := cal.BusinessDaysBetween(0, 1705307400000000000); got != 0 {
+		t.Errorf("BusinessDaysBetween without business days = %d; want 0", got)
+	}
+}
@@ -0,0 +1,255 @@
+package tinytime
+
+import . "github.com/cdvelop/tinystring"
+
+// addDate shifts the calendar date of nano by years, months and days in the zone described by offset,
+// keeping the wall-clock time of day. When the target month is shorter, the day is clamped to its
+// last day, so Jan 31 + 1 month is Feb 29 (or Feb 28) instead of rolling into March.
+func addDate(nano int64, years, months, days int, offset func(nano int64) int) int64 {
+	localDays, nanoOfDay := splitNano(nano + int64(offset(nano))*1000000000)
+	year, month, day := civilFromDays(localDays)
+
+	if years != 0 || months != 0 {
+		total := year*12 + month - 1 + years*12 + months
+		year = total / 12
+		if total%12 < 0 {
+			year--
+		}
+		month = total - year*12 + 1
+		if last := daysInMonth(year, month); day > last {
+			day = last
+		}
+	}
+
+	localDays = daysFromCivil(year, month, day) + int64(days)
+	return utcFromLocal(localDays*nanosPerDay+nanoOfDay, offset)
+}
+
+// Weekday specifies a day of the week (Sunday = 0), with the same values as Go's time.Weekday.
+type Weekday int
+
+const (
+	Sunday Weekday = iota
+	Monday
+	Tuesday
+	Wednesday
+	Thursday
+	Friday
+	Saturday
+)
+
+// String returns the English name of the day ("Sunday", "Monday", ...).
+func (d Weekday) String() string {
+	if d < Sunday || d > Saturday {
+		return ""
+	}
+	return weekdayNames[d]
+}
+
+// weekdayFromDays returns the weekday of a day count since 1970-01-01 (a Thursday).
+func weekdayFromDays(days int64) Weekday {
+	return Weekday((days%7 + 11) % 7)
+}
+
+// period identifies a calendar period for StartOf/EndOf calculations.
+type period uint8
+
+const (
+	periodDay period = iota
+	periodWeek
+	periodMonth
+	periodQuarter
+	periodYear
+)
+
+// periodBounds returns the first instant of the period containing nano and the first instant
+// of the following period, in the zone described by offset. Weeks start on firstDay.
+func periodBounds(nano int64, p period, firstDay Weekday, offset func(nano int64) int) (start, next int64) {
+	localDays, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	year, month, _ := civilFromDays(localDays)
+
+	var startDays, nextDays int64
+	switch p {
+	case periodDay:
+		startDays, nextDays = localDays, localDays+1
+	case periodWeek:
+		startDays = localDays - int64((weekdayFromDays(localDays)-firstDay+7)%7)
+		nextDays = startDays + 7
+	case periodMonth:
+		startDays = daysFromCivil(year, month, 1)
+		nextDays = daysFromCivil(year, month+1, 1)
+	case periodQuarter:
+		month = (month-1)/3*3 + 1
+		startDays = daysFromCivil(year, month, 1)
+		nextDays = daysFromCivil(year, month+3, 1)
+	case periodYear:
+		startDays = daysFromCivil(year, 1, 1)
+		nextDays = daysFromCivil(year+1, 1, 1)
+	}
+	return utcFromLocal(startDays*nanosPerDay, offset), utcFromLocal(nextDays*nanosPerDay, offset)
+}
+
+// startOf returns the first instant of the period containing nano.
+func startOf(nano int64, p period, firstDay Weekday, offset func(nano int64) int) int64 {
+	start, _ := periodBounds(nano, p, firstDay, offset)
+	return start
+}
+
+// endOf returns the last nanosecond of the period containing nano.
+func endOf(nano int64, p period, firstDay Weekday, offset func(nano int64) int) int64 {
+	_, next := periodBounds(nano, p, firstDay, offset)
+	return next - 1
+}
+
+// DateDiff holds the calendar difference between two instants, as returned by DiffComponents.
+// All fields share the same sign: negative when the second instant is earlier.
+type DateDiff struct {
+	Years   int
+	Months  int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds int
+}
+
+// calendarDaysBetween counts the date boundaries crossed from nano1 to nano2 in the zone described by offset.
+func calendarDaysBetween(nano1, nano2 int64, offset func(nano int64) int) int {
+	days1, _ := splitNano(nano1 + int64(offset(nano1))*1000000000)
+	days2, _ := splitNano(nano2 + int64(offset(nano2))*1000000000)
+	return int(days2 - days1)
+}
+
+// diffComponents splits the span from nano1 to nano2 into calendar years, months, days and wall-clock
+// hours, minutes and seconds in the zone described by offset. Whole months are counted first with
+// end-of-month clamping (Jan 31 to Feb 29 is 1 month), then the remainder is split into days and time.
+// A span that ends at an earlier wall-clock time because of a DST overlap reports its elapsed time.
+func diffComponents(nano1, nano2 int64, offset func(nano int64) int) DateDiff {
+	sign := 1
+	if nano2 < nano1 {
+		nano1, nano2 = nano2, nano1
+		sign = -1
+	}
+	local1 := nano1 + int64(offset(nano1))*1000000000
+	local2 := nano2 + int64(offset(nano2))*1000000000
+
+	days1, _ := splitNano(local1)
+	days2, _ := splitNano(local2)
+	year1, month1, _ := civilFromDays(days1)
+	year2, month2, _ := civilFromDays(days2)
+
+	var months int
+	var rest int64
+	if local2 < local1 {
+		// The wall clock went back (DST overlap) by more than the span: only the elapsed time counts
+		rest = (nano2 - nano1) / 1000000000
+	} else {
+		months = (year2-year1)*12 + month2 - month1
+		anchor := addDate(local1, 0, months, 0, utcOffset)
+		if anchor > local2 {
+			months--
+			anchor = addDate(local1, 0, months, 0, utcOffset)
+		}
+		rest = (local2 - anchor) / 1000000000
+	}
+	return DateDiff{
+		Years:   sign * (months / 12),
+		Months:  sign * (months % 12),
+		Days:    sign * int(rest/86400),
+		Hours:   sign * int(rest/3600%24),
+		Minutes: sign * int(rest/60%60),
+		Seconds: sign * int(rest%60),
+	}
+}
+
+// isoWeekday returns the ISO 8601 number of the weekday of a day count: Monday = 1 ... Sunday = 7.
+func isoWeekday(days int64) int {
+	return int(weekdayFromDays(days)+6)%7 + 1
+}
+
+// isoWeekOf returns the ISO 8601 week-numbering year and week of a day count since 1970-01-01.
+// The week belongs to the year of its Thursday, so Jan 1st can fall in week 52 or 53 of the previous year.
+func isoWeekOf(days int64) (year, week int) {
+	thursday := days - int64(isoWeekday(days)) + 4
+	year, _, _ = civilFromDays(thursday)
+	return year, int((thursday-daysFromCivil(year, 1, 1))/7) + 1
+}
+
+// isoWeeksInYear returns 52 or 53, the number of ISO weeks of the week-numbering year.
+func isoWeeksInYear(year int) int {
+	_, week := isoWeekOf(daysFromCivil(year, 12, 28)) // Dec 28th is always in the last week
+	return week
+}
+
+// daysFromISOWeek returns the day count of an ISO week date; weekday runs from 1 (Monday) to 7 (Sunday).
+func daysFromISOWeek(year, week, weekday int) int64 {
+	jan4 := daysFromCivil(year, 1, 4) // Jan 4th is always in week 1
+	return jan4 - int64(isoWeekday(jan4)) + int64((week-1)*7+weekday)
+}
+
+// formatISOWeekDate formats the local day of nano as an ISO week date: "2024-W03-1".
+func formatISOWeekDate(nano int64, offset func(nano int64) int) string {
+	days, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	year, week := isoWeekOf(days)
+	buf := appendInt(make([]byte, 0, 10), year, 4)
+	buf = appendInt(append(buf, "-W"...), week, 2)
+	return string(appendInt(append(buf, '-'), isoWeekday(days), 1))
+}
+
+// parseISOWeekDate parses "YYYY-Www-D", "YYYY-Www" (Monday) or the basic forms "YYYYWwwD" and "YYYYWww"
+// into the UnixNano of midnight of that day in the zone described by offset.
+func parseISOWeekDate(s string, offset func(nano int64) int) (int64, error) {
+	year, rest, ok := parseDigits(s, 4, 4)
+	if !ok {
+		return 0, Errf("invalid week date: %s", s)
+	}
+	extended := len(rest) > 0 && rest[0] == '-'
+	if extended {
+		rest = rest[1:]
+	}
+	if len(rest) == 0 || (rest[0] != 'W' && rest[0] != 'w') {
+		return 0, Errf("invalid week date: %s", s)
+	}
+	week, rest, ok := parseDigits(rest[1:], 2, 2)
+	if !ok || week < 1 || week > isoWeeksInYear(year) {
+		return 0, Errf("invalid week: %s", s)
+	}
+	weekday := 1
+	if rest != "" {
+		if extended {
+			if rest[0] != '-' {
+				return 0, Errf("invalid week date: %s", s)
+			}
+			rest = rest[1:]
+		}
+		if weekday, rest, ok = parseDigits(rest, 1, 1); !ok || rest != "" || weekday < 1 || weekday > 7 {
+			return 0, Errf("invalid week day: %s", s)
+		}
+	}
+	return utcFromLocal(daysFromISOWeek(year, week, weekday)*nanosPerDay, offset), nil
+}
+
+// weekdayOf returns the local weekday of nano in the zone described by offset.
+func weekdayOf(nano int64, offset func(nano int64) int) Weekday {
+	days, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	return weekdayFromDays(days)
+}
+
+// isoWeek returns the ISO 8601 week-numbering year and week of the local day of nano.
+func isoWeek(nano int64, offset func(nano int64) int) (year, week int) {
+	days, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	return isoWeekOf(days)
+}
+
+// quarter returns the quarter (1 to 4) of the local date of nano.
+func quarter(nano int64, offset func(nano int64) int) int {
+	days, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	_, month, _ := civilFromDays(days)
+	return (month-1)/3 + 1
+}
+
+// dayOfYear returns the local day of the year of nano, from 1 (Jan 1st) to 366.
+func dayOfYear(nano int64, offset func(nano int64) int) int {
+	days, _ := splitNano(nano + int64(offset(nano))*1000000000)
+	year, _, _ := civilFromDays(days)
+	return int(days-daysFromCivil(year, 1, 1)) + 1
+}
@@ -0,0 +1,247 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// Test AddDays, AddMonths and AddYears
+func AddDateShared(t *testing.T, tp tinytime.TimeProvider) {
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	tests := []struct {
+		name  string
+		add   func(nano int64, n int) int64
+		start string
+		n     int
+		want  string
+	}{
+		{"AddDays", tp.AddDays, "2024-01-15 08:30", 1, "2024-01-16 08:30"},
+		{"AddDays", tp.AddDays, "2024-01-15 08:30", 30, "2024-02-14 08:30"},
+		{"AddDays", tp.AddDays, "2024-03-01 00:00", -1, "2024-02-29 00:00"},
+		{"AddDays", tp.AddDays, "2024-12-31 23:59", 1, "2025-01-01 23:59"},
+		{"AddMonths", tp.AddMonths, "2024-01-31 10:00", 1, "2024-02-29 10:00"},
+		{"AddMonths", tp.AddMonths, "2023-01-31 10:00", 1, "2023-02-28 10:00"},
+		{"AddMonths", tp.AddMonths, "2024-03-31 10:00", -1, "2024-02-29 10:00"},
+		{"AddMonths", tp.AddMonths, "2024-05-31 10:00", 1, "2024-06-30 10:00"},
+		{"AddMonths", tp.AddMonths, "2024-11-15 10:00", 3, "2025-02-15 10:00"},
+		{"AddMonths", tp.AddMonths, "2024-01-15 10:00", -13, "2022-12-15 10:00"},
+		{"AddMonths", tp.AddMonths, "2024-01-15 10:00", 0, "2024-01-15 10:00"},
+		{"AddYears", tp.AddYears, "2024-02-29 12:00", 1, "2025-02-28 12:00"},
+		{"AddYears", tp.AddYears, "2024-02-29 12:00", 4, "2028-02-29 12:00"},
+		{"AddYears", tp.AddYears, "2024-07-04 12:00", -30, "1994-07-04 12:00"},
+	}
+
+	for _, tt := range tests {
+		got := tp.FormatDateTimeShort(tt.add(parse(tt.start), tt.n))
+		if got != tt.want {
+			t.Errorf("%s(%s, %d) = %s; want %s", tt.name, tt.start, tt.n, got, tt.want)
+		}
+	}
+
+	// Seconds and fractions are preserved
+	nano := parse("2024-01-31T08:30:15.5Z")
+	if got := tp.AddMonths(nano, 1) - tp.AddMonths(nano-500000000, 1); got != 500000000 {
+		t.Errorf("AddMonths lost sub-second precision: delta %d", got)
+	}
+
+	t.Logf("AddDate tests passed")
+}
+
+// Test StartOf/EndOf period boundaries
+func PeriodBoundsShared(t *testing.T, tp tinytime.TimeProvider) {
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+	const layout = "YYYY-MM-DD HH:mm:ss.SSSSSSSSS"
+
+	nano := parse("2024-05-15 14:25:36") // Wednesday, Q2
+	tests := []struct {
+		name string
+		got  int64
+		want string
+	}{
+		{"StartOfDay", tp.StartOfDay(nano), "2024-05-15 00:00:00.000000000"},
+		{"EndOfDay", tp.EndOfDay(nano), "2024-05-15 23:59:59.999999999"},
+		{"StartOfWeek(Monday)", tp.StartOfWeek(nano, tinytime.Monday), "2024-05-13 00:00:00.000000000"},
+		{"EndOfWeek(Monday)", tp.EndOfWeek(nano, tinytime.Monday), "2024-05-19 23:59:59.999999999"},
+		{"StartOfWeek(Sunday)", tp.StartOfWeek(nano, tinytime.Sunday), "2024-05-12 00:00:00.000000000"},
+		{"StartOfWeek(Wednesday)", tp.StartOfWeek(nano, tinytime.Wednesday), "2024-05-15 00:00:00.000000000"},
+		{"StartOfWeek(Thursday)", tp.StartOfWeek(nano, tinytime.Thursday), "2024-05-09 00:00:00.000000000"},
+		{"StartOfMonth", tp.StartOfMonth(nano), "2024-05-01 00:00:00.000000000"},
+		{"EndOfMonth", tp.EndOfMonth(nano), "2024-05-31 23:59:59.999999999"},
+		{"EndOfMonth(Feb)", tp.EndOfMonth(parse("2024-02-10")), "2024-02-29 23:59:59.999999999"},
+		{"StartOfQuarter", tp.StartOfQuarter(nano), "2024-04-01 00:00:00.000000000"},
+		{"EndOfQuarter", tp.EndOfQuarter(nano), "2024-06-30 23:59:59.999999999"},
+		{"EndOfQuarter(Q4)", tp.EndOfQuarter(parse("2024-11-02")), "2024-12-31 23:59:59.999999999"},
+		{"StartOfYear", tp.StartOfYear(nano), "2024-01-01 00:00:00.000000000"},
+		{"EndOfYear", tp.EndOfYear(nano), "2024-12-31 23:59:59.999999999"},
+		// Before the epoch
+		{"StartOfDay(1969)", tp.StartOfDay(-1), "1969-12-31 00:00:00.000000000"},
+	}
+
+	for _, tt := range tests {
+		if got := tp.Format(tt.got, layout); got != tt.want {
+			t.Errorf("%s = %s; want %s", tt.name, got, tt.want)
+		}
+	}
+
+	// Boundaries are idempotent
+	start := tp.StartOfMonth(nano)
+	if tp.StartOfMonth(start) != start {
+		t.Error("StartOfMonth(StartOfMonth(x)) should equal StartOfMonth(x)")
+	}
+	end := tp.EndOfMonth(nano)
+	if tp.StartOfMonth(end) != start || tp.StartOfMonth(end+1) == start {
+		t.Error("EndOfMonth should be the last nanosecond of the month")
+	}
+
+	if tinytime.Monday.String() != "Monday" || tinytime.Weekday(9).String() != "" {
+		t.Error("Weekday.String returned unexpected value")
+	}
+
+	t.Logf("PeriodBounds tests passed")
+}
+
+// Test CalendarDaysBetween and DiffComponents
+func CalendarDiffShared(t *testing.T, tp tinytime.TimeProvider) {
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	days := []struct {
+		from, to string
+		want     int
+	}{
+		{"2024-01-15 23:59", "2024-01-16 00:01", 1},
+		{"2024-01-16 00:01", "2024-01-15 23:59", -1},
+		{"2024-01-15 00:00", "2024-01-15 23:59", 0},
+		{"2024-01-15 12:00", "2024-01-22 08:00", 7},
+		{"2024-02-28 12:00", "2024-03-01 12:00", 2},
+		{"2023-12-31 23:00", "2024-01-01 01:00", 1},
+	}
+	for _, tt := range days {
+		if got := tp.CalendarDaysBetween(parse(tt.from), parse(tt.to)); got != tt.want {
+			t.Errorf("CalendarDaysBetween(%s, %s) = %d; want %d", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	diffs := []struct {
+		from, to string
+		want     tinytime.DateDiff
+	}{
+		{"1990-05-20 00:00:00", "2024-01-15 08:30:15", tinytime.DateDiff{Years: 33, Months: 7, Days: 26, Hours: 8, Minutes: 30, Seconds: 15}},
+		{"2024-01-31 10:00:00", "2024-02-29 10:00:00", tinytime.DateDiff{Months: 1}},
+		{"2024-01-31 10:00:00", "2024-03-01 09:00:00", tinytime.DateDiff{Months: 1, Hours: 23}},
+		{"2024-01-15 23:59:00", "2024-01-16 00:01:00", tinytime.DateDiff{Minutes: 2}},
+		{"2020-02-29 00:00:00", "2024-02-28 00:00:00", tinytime.DateDiff{Years: 3, Months: 11, Days: 30}},
+		{"2024-01-15 08:30:00", "2024-01-15 08:30:00", tinytime.DateDiff{}},
+		{"2024-03-10 12:00:00", "2023-01-05 06:00:00", tinytime.DateDiff{Years: -1, Months: -2, Days: -5, Hours: -6}},
+	}
+	for _, tt := range diffs {
+		if got := tp.DiffComponents(parse(tt.from), parse(tt.to)); got != tt.want {
+			t.Errorf("DiffComponents(%s, %s) = %+v; want %+v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	t.Logf("CalendarDiff tests passed")
+}
+
+// Test Weekday, ISOWeek, Quarter, DayOfYear and ISO week-date strings
+func ISOWeekShared(t *testing.T, tp tinytime.TimeProvider) {
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	tests := []struct {
+		date      string
+		weekday   tinytime.Weekday
+		isoYear   int
+		week      int
+		quarter   int
+		dayOfYear int
+		weekDate  string
+	}{
+		{"2024-01-15 08:30", tinytime.Monday, 2024, 3, 1, 15, "2024-W03-1"},
+		{"2024-01-01 00:00", tinytime.Monday, 2024, 1, 1, 1, "2024-W01-1"},
+		{"2023-01-01 12:00", tinytime.Sunday, 2022, 52, 1, 1, "2022-W52-7"},
+		{"2021-01-03 12:00", tinytime.Sunday, 2020, 53, 1, 3, "2020-W53-7"},
+		{"2024-12-30 23:59", tinytime.Monday, 2025, 1, 4, 365, "2025-W01-1"},
+		{"2024-12-31 12:00", tinytime.Tuesday, 2025, 1, 4, 366, "2025-W01-2"},
+		{"2024-06-30 12:00", tinytime.Sunday, 2024, 26, 2, 182, "2024-W26-7"},
+		{"2024-07-01 00:00", tinytime.Monday, 2024, 27, 3, 183, "2024-W27-1"},
+		{"2026-12-31 12:00", tinytime.Thursday, 2026, 53, 4, 365, "2026-W53-4"},
+		{"1969-12-31 12:00", tinytime.Wednesday, 1970, 1, 4, 365, "1970-W01-3"},
+	}
+
+	for _, tt := range tests {
+		nano := parse(tt.date)
+		if got := tp.Weekday(nano); got != tt.weekday {
+			t.Errorf("Weekday(%s) = %s; want %s", tt.date, got, tt.weekday)
+		}
+		if year, week := tp.ISOWeek(nano); year != tt.isoYear || week != tt.week {
+			t.Errorf("ISOWeek(%s) = %d, %d; want %d, %d", tt.date, year, week, tt.isoYear, tt.week)
+		}
+		if got := tp.Quarter(nano); got != tt.quarter {
+			t.Errorf("Quarter(%s) = %d; want %d", tt.date, got, tt.quarter)
+		}
+		if got := tp.DayOfYear(nano); got != tt.dayOfYear {
+			t.Errorf("DayOfYear(%s) = %d; want %d", tt.date, got, tt.dayOfYear)
+		}
+		if got := tp.FormatISOWeekDate(nano); got != tt.weekDate {
+			t.Errorf("FormatISOWeekDate(%s) = %s; want %s", tt.date, got, tt.weekDate)
+		}
+		got, err := tp.ParseISOWeekDate(tt.weekDate)
+		if err != nil {
+			t.Errorf("ParseISOWeekDate(%s) failed: %v", tt.weekDate, err)
+		} else if got != tp.StartOfDay(nano) {
+			t.Errorf("ParseISOWeekDate(%s) = %s; want %s", tt.weekDate, tp.FormatDateTime(got), tp.FormatDateTime(tp.StartOfDay(nano)))
+		}
+	}
+
+	// Week-only and basic forms
+	forms := map[string]string{
+		"2024-W03":   "2024-01-15",
+		"2024W031":   "2024-01-15",
+		"2024w03":    "2024-01-15",
+		"2025-W01-1": "2024-12-30",
+		"2020-W53-5": "2021-01-01",
+	}
+	for input, want := range forms {
+		nano, err := tp.ParseISOWeekDate(input)
+		if err != nil {
+			t.Errorf("ParseISOWeekDate(%s) failed: %v", input, err)
+		} else if got := tp.FormatDate(nano); got != want {
+			t.Errorf("ParseISOWeekDate(%s) = %s; want %s", input, got, want)
+		}
+	}
+
+	invalid := []string{"", "2024", "2024-03-1", "2024-W3-1", "2024-W00-1", "2024-W53-1", "2024-W03-8",
+		"2024-W03-0", "2024-W031", "2024W03-1", "2024-W03-1x", "24-W03-1"}
+	for _, input := range invalid {
+		if _, err := tp.ParseISOWeekDate(input); err == nil {
+			t.Errorf("ParseISOWeekDate(%q) should fail", input)
+		}
+	}
+
+	t.Logf("ISOWeek tests passed")
+}
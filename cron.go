@@ -0,0 +1,308 @@
+package tinytime
+
+import (
+	"sync"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// Cron is a parsed cron expression evaluated in the zone of the provider it was parsed with.
+type Cron struct {
+	second, minute, hour, dom, month, dow uint64 // bit n set when value n matches
+	domAny, dowAny                        bool   // field was "*" or "?"
+	offset                                func(nano int64) int
+}
+
+// cronMacros maps the supported @ shortcuts to their 5-field form.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSearchDays bounds the search of Next: a leap day can be 8 years away.
+const cronSearchDays = 9 * 366
+
+// ParseCron parses a cron expression evaluated in the provider's zone. It accepts 5 fields
+// (minute hour day-of-month month day-of-week), 6 fields with a leading seconds field, and the
+// macros @yearly (@annually), @monthly, @weekly, @daily (@midnight) and @hourly.
+// Fields support "*", "?", lists ("1,15"), ranges ("1-5"), steps ("*/15", "10-50/20") and the names
+// JAN-DEC and SUN-SAT; day-of-week 7 is also Sunday. As in standard cron, when both day-of-month and
+// day-of-week are restricted, a day matching either one fires.
+func ParseCron(tp TimeProvider, expr string) (*Cron, error) {
+	fields := Convert(expr).Split()
+	if len(fields) == 1 {
+		macro, ok := cronMacros[Convert(fields[0]).ToLower().String()]
+		if !ok {
+			return nil, Errf("unknown cron macro: %s", fields[0])
+		}
+		fields = Convert(macro).Split()
+	}
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, Errf("cron expression needs 5 or 6 fields: %s", expr)
+	}
+
+	c := &Cron{offset: tp.Offset}
+	var ok bool
+	if c.second, _, ok = parseCronField(fields[0], 0, 59, nil); !ok {
+		return nil, Errf("invalid cron seconds: %s", fields[0])
+	}
+	if c.minute, _, ok = parseCronField(fields[1], 0, 59, nil); !ok {
+		return nil, Errf("invalid cron minutes: %s", fields[1])
+	}
+	if c.hour, _, ok = parseCronField(fields[2], 0, 23, nil); !ok {
+		return nil, Errf("invalid cron hours: %s", fields[2])
+	}
+	if c.dom, c.domAny, ok = parseCronField(fields[3], 1, 31, nil); !ok {
+		return nil, Errf("invalid cron day of month: %s", fields[3])
+	}
+	if c.month, _, ok = parseCronField(fields[4], 1, 12, monthNames[:]); !ok {
+		return nil, Errf("invalid cron month: %s", fields[4])
+	}
+	if c.dow, c.dowAny, ok = parseCronField(fields[5], 0, 7, weekdayNames[:]); !ok {
+		return nil, Errf("invalid cron day of week: %s", fields[5])
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	return c, nil
+}
+
+// parseCronField parses one field into a bit set of the values between lo and hi.
+// names, when given, are matched by their 3-letter abbreviation and numbered from lo.
+// As in Vixie cron, a field starting with "*" or "?" ("*/2") counts as unrestricted for the day rule.
+func parseCronField(field string, lo, hi int, names []string) (bits uint64, wildcard bool, ok bool) {
+	wildcard = field != "" && (field[0] == '*' || field[0] == '?')
+	for _, part := range Convert(field).Split(",") {
+		step := 1
+		if i := Index(part, "/"); i >= 0 {
+			n, rest, digitsOK := parseDigits(part[i+1:], 1, 2)
+			if !digitsOK || rest != "" || n < 1 {
+				return 0, false, false
+			}
+			step, part = n, part[:i]
+		}
+
+		var first, last int
+		switch i := Index(part, "-"); {
+		case part == "*" || part == "?":
+			first, last = lo, hi
+		case i > 0:
+			first, ok = parseCronValue(part[:i], lo, names)
+			if !ok {
+				return 0, false, false
+			}
+			if last, ok = parseCronValue(part[i+1:], lo, names); !ok {
+				return 0, false, false
+			}
+		default:
+			if first, ok = parseCronValue(part, lo, names); !ok {
+				return 0, false, false
+			}
+			last = first
+			if step > 1 { // "10/5" runs from 10 to the end of the range
+				last = hi
+			}
+		}
+		if first < lo || last > hi || first > last {
+			return 0, false, false
+		}
+		for v := first; v <= last; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, wildcard, true
+}
+
+// parseCronValue reads a number or a 3-letter name (numbered from lo).
+func parseCronValue(s string, lo int, names []string) (int, bool) {
+	if n, rest, ok := parseDigits(s, 1, 2); ok && rest == "" {
+		return n, true
+	}
+	if len(names) > 0 && len(s) == 3 {
+		if i, rest, ok := parseName(s, names, true); ok && rest == "" {
+			return i + lo, true
+		}
+	}
+	return 0, false
+}
+
+// Next returns the first UnixNano after the given one that matches the expression,
+// or 0 if none does within the next 9 years (e.g. "0 0 30 2 *").
+// Times skipped by a DST gap run at the first instant after the gap; times repeated by a
+// DST overlap run once.
+func (c *Cron) Next(after int64) int64 {
+	days, nanoOfDay := splitNano(after + int64(c.offset(after))*1000000000)
+	sec := nanoOfDay/1000000000 + 1 // second of day; the next whole second is the first candidate
+	limit := days + cronSearchDays
+
+	for days <= limit {
+		if sec >= 86400 {
+			days, sec = days+1, 0
+			continue
+		}
+		year, month, day := civilFromDays(days)
+		if c.month&(1<<month) == 0 {
+			days, sec = daysFromCivil(year, month+1, 1), 0
+			continue
+		}
+		if !c.dayMatches(days, day) {
+			days, sec = days+1, 0
+			continue
+		}
+		hour, minute := sec/3600, sec/60%60
+		if c.hour&(1<<hour) == 0 {
+			sec = (hour + 1) * 3600
+			continue
+		}
+		if c.minute&(1<<minute) == 0 {
+			sec = hour*3600 + (minute+1)*60
+			continue
+		}
+		if c.second&(1<<(sec%60)) == 0 {
+			sec++
+			continue
+		}
+		if nano := utcFromLocal(days*nanosPerDay+sec*1000000000, c.offset); nano > after {
+			return nano
+		}
+		sec++ // the wall-clock time maps to an earlier instant (DST overlap)
+	}
+	return 0
+}
+
+// dayMatches applies the day-of-month and day-of-week fields to a local day.
+func (c *Cron) dayMatches(days int64, day int) bool {
+	domOK := c.dom&(1<<day) != 0
+	dowOK := c.dow&(1<<weekdayFromDays(days)) != 0
+	if !c.domAny && !c.dowAny {
+		return domOK || dowOK
+	}
+	return domOK && dowOK
+}
+
+// maxTimerDelay keeps timers within the 32-bit millisecond limit of setTimeout (about 24 days);
+// longer waits are covered by re-arming.
+const maxTimerDelay = 1<<31 - 1
+
+// Scheduler runs cron jobs by arming one TimeProvider.AfterFunc timer per job, so the same job
+// definitions work with time.AfterFunc on the server, setTimeout in WASM and FakeTimeProvider in tests.
+type Scheduler struct {
+	tp   TimeProvider
+	mu   sync.Mutex
+	jobs []*CronJob
+}
+
+// NewScheduler returns a Scheduler driven by the provider's clock and zone.
+func NewScheduler(tp TimeProvider) *Scheduler {
+	return &Scheduler{tp: tp}
+}
+
+// Add schedules f to run at every time matching the cron expression (see ParseCron).
+func (s *Scheduler) Add(expr string, f func()) (*CronJob, error) {
+	c, err := ParseCron(s.tp, expr)
+	if err != nil {
+		return nil, err
+	}
+	job := &CronJob{tp: s.tp, cron: c, f: f, active: true}
+	job.mu.Lock()
+	job.arm(s.tp.UnixNano())
+	job.mu.Unlock()
+
+	s.mu.Lock()
+	s.jobs = append(s.jobs, job)
+	s.mu.Unlock()
+	return job, nil
+}
+
+// Stop stops every job added to the scheduler.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	jobs := s.jobs
+	s.jobs = nil
+	s.mu.Unlock()
+	for _, job := range jobs {
+		job.Stop()
+	}
+}
+
+// CronJob is a job added to a Scheduler.
+type CronJob struct {
+	tp     TimeProvider
+	cron   *Cron
+	f      func()
+	mu     sync.Mutex
+	timer  Timer
+	next   int64 // UnixNano of the next run; 0 when the expression has no more matches
+	active bool
+}
+
+// arm schedules the run following base. Caller must hold j.mu.
+func (j *CronJob) arm(base int64) {
+	j.next = j.cron.Next(base)
+	if j.next == 0 {
+		j.active = false
+		return
+	}
+	j.wait()
+}
+
+// wait arms the timer for j.next, rounding the delay up to whole milliseconds. Caller must hold j.mu.
+func (j *CronJob) wait() {
+	delay := (j.next - j.tp.UnixNano() + 999999) / 1000000
+	j.timer = j.tp.AfterFunc(int(min(max(delay, 0), maxTimerDelay)), j.run)
+}
+
+func (j *CronJob) run() {
+	j.mu.Lock()
+	if !j.active {
+		j.mu.Unlock()
+		return
+	}
+	now := j.tp.UnixNano()
+	if now < j.next { // woke up early or for a capped long delay
+		j.wait()
+		j.mu.Unlock()
+		return
+	}
+	// Re-arm from now: runs missed while the process was suspended are skipped, not replayed
+	j.arm(now)
+	f := j.f
+	j.mu.Unlock()
+
+	if f != nil {
+		f()
+	}
+}
+
+// Next returns the UnixNano of the next run, or 0 if the job is stopped or has no more runs.
+func (j *CronJob) Next() int64 {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.active {
+		return 0
+	}
+	return j.next
+}
+
+// Stop cancels the job. Returns false if it was already stopped.
+func (j *CronJob) Stop() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if !j.active {
+		return false
+	}
+	j.active = false
+	if j.timer != nil {
+		j.timer.Stop()
+	}
+	return true
+}
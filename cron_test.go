@@ -0,0 +1,153 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+func TestCron_Next(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	after, _ := tp.Parse("2024-01-15 08:30:20") // Monday
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "2024-01-15 08:31:00"},
+		{"*/15 * * * *", "2024-01-15 08:45:00"},
+		{"30 8 * * *", "2024-01-16 08:30:00"},
+		{"0 9-17 * * MON-FRI", "2024-01-15 09:00:00"},
+		{"0 0 * * 0", "2024-01-21 00:00:00"},
+		{"0 0 * * 7", "2024-01-21 00:00:00"},
+		{"0 0 1 * *", "2024-02-01 00:00:00"},
+		{"0 12 29 feb *", "2024-02-29 12:00:00"},
+		{"0 0 13 * FRI", "2024-01-19 00:00:00"}, // day-of-month OR day-of-week
+		{"0 0 ? * 5", "2024-01-19 00:00:00"},
+		{"0 0 */2 * MON", "2024-01-29 00:00:00"}, // a stepped wildcard still restricts with AND
+		{"10/20 * * * *", "2024-01-15 08:50:00"},
+		{"0 0 1,15 * *", "2024-02-01 00:00:00"},
+		{"*/10 * * * * *", "2024-01-15 08:30:30"},
+		{"45 30 8 * * *", "2024-01-15 08:30:45"},
+		{"@hourly", "2024-01-15 09:00:00"},
+		{"@daily", "2024-01-16 00:00:00"},
+		{"@weekly", "2024-01-21 00:00:00"},
+		{"@monthly", "2024-02-01 00:00:00"},
+		{"@yearly", "2025-01-01 00:00:00"},
+	}
+	for _, tt := range tests {
+		c, err := tinytime.ParseCron(tp, tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got := tp.FormatDateTime(c.Next(after)); got != tt.want {
+			t.Errorf("Next(%q) = %s; want %s", tt.expr, got, tt.want)
+		}
+	}
+
+	// Next is strictly after its argument
+	c, _ := tinytime.ParseCron(tp, "30 8 * * *")
+	exact, _ := tp.Parse("2024-01-15 08:30:00")
+	if got := tp.FormatDateTime(c.Next(exact)); got != "2024-01-16 08:30:00" {
+		t.Errorf("Next(match) = %s; want 2024-01-16 08:30:00", got)
+	}
+
+	// Leap day from a non-leap year, and an expression that never matches
+	c, _ = tinytime.ParseCron(tp, "0 0 29 2 *")
+	from, _ := tp.Parse("2025-03-01 00:00")
+	if got := tp.FormatDate(c.Next(from)); got != "2028-02-29" {
+		t.Errorf("Next(leap day) = %s; want 2028-02-29", got)
+	}
+	c, _ = tinytime.ParseCron(tp, "0 0 30 2 *")
+	if got := c.Next(from); got != 0 {
+		t.Errorf("Next(Feb 30) = %d; want 0", got)
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	for _, expr := range []string{
+		"", "* * * *", "* * * * * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * 32 * *",
+		"* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "* * * JANUARY *",
+		"@every", "1,,2 * * * *",
+	} {
+		if _, err := tinytime.ParseCron(tp, expr); err == nil {
+			t.Errorf("ParseCron(%q) should return error", expr)
+		}
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(1705307400000000000) // 2024-01-15 08:30:00 UTC
+	s := tinytime.NewScheduler(tp)
+
+	var runs []string
+	job, err := s.Add("*/15 * * * *", func() { runs = append(runs, tp.FormatTime(tp.UnixNano())) })
+	if err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	if got := tp.FormatTime(job.Next()); got != "08:45:00" {
+		t.Errorf("Next() = %s; want 08:45:00", got)
+	}
+
+	tp.Advance(60 * 60 * 1000)
+	want := []string{"08:45:00", "09:00:00", "09:15:00", "09:30:00"}
+	if len(runs) != len(want) {
+		t.Fatalf("runs = %v; want %v", runs, want)
+	}
+	for i := range want {
+		if runs[i] != want[i] {
+			t.Errorf("runs[%d] = %s; want %s", i, runs[i], want[i])
+		}
+	}
+
+	if !job.Stop() {
+		t.Error("Stop() should return true for active job")
+	}
+	if job.Stop() {
+		t.Error("Stop() should return false for stopped job")
+	}
+	if job.Next() != 0 {
+		t.Errorf("Next() after Stop = %d; want 0", job.Next())
+	}
+	tp.Advance(60 * 60 * 1000)
+	if len(runs) != 4 {
+		t.Errorf("job ran after Stop: %v", runs)
+	}
+	if tp.Pending() != 0 {
+		t.Errorf("Pending() = %d; want 0", tp.Pending())
+	}
+
+	if _, err := s.Add("not cron", nil); err == nil {
+		t.Error("Add(invalid) should return error")
+	}
+}
+
+func TestScheduler_LongDelay(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(1705307400000000000) // 2024-01-15 08:30:00 UTC
+	s := tinytime.NewScheduler(tp)
+
+	// Monthly runs are further away than a single setTimeout can wait
+	var runs []string
+	s.Add("@monthly", func() { runs = append(runs, tp.FormatDateTime(tp.UnixNano())) })
+	s.Add("0 0 * * *", nil) // nil jobs must not panic
+
+	for range 100 {
+		tp.Advance(24 * 60 * 60 * 1000)
+	}
+	want := []string{"2024-02-01 00:00:00", "2024-03-01 00:00:00", "2024-04-01 00:00:00"}
+	if len(runs) != len(want) {
+		t.Fatalf("runs = %v; want %v", runs, want)
+	}
+	for i := range want {
+		if runs[i] != want[i] {
+			t.Errorf("runs[%d] = %s; want %s", i, runs[i], want[i])
+		}
+	}
+
+	s.Stop()
+	if tp.Pending() != 0 {
+		t.Errorf("Pending() after Scheduler.Stop = %d; want 0", tp.Pending())
+	}
+}
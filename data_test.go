@@ -312,6 +312,12 @@ func ParseTimeShared(t *testing.T, tp tinytime.TimeProvider) {
 		t.Error("ParseTime(00:60) should return error")
 	}
 
+	// Test invalid seconds
+	_, err = tp.ParseTime("08:30:99")
+	if err == nil {
+		t.Error("ParseTime(08:30:99) should return error")
+	}
+
 	t.Logf("ParseTime tests passed")
 }
 
@@ -347,6 +353,12 @@ func ParseDateTimeShared(t *testing.T, tp tinytime.TimeProvider) {
 		t.Error("ParseDateTime(invalid time) should return error")
 	}
 
+	// Invalid date (Feb 30)
+	_, err = tp.ParseDateTime("2024-02-30", "02:30")
+	if err == nil {
+		t.Error("ParseDateTime(2024-02-30) should return error")
+	}
+
 	t.Logf("ParseDateTime tests passed")
 }
 
@@ -0,0 +1,91 @@
+package tinytime
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Date is a civil calendar date without time or zone, stored as days since 1970-01-01.
+// It takes 4 bytes instead of the 8 of a UnixNano midnight and cannot drift across zones.
+type Date int32
+
+// NewDate returns the Date for year, month (1-12) and day (1-31), rejecting days that do not exist
+// in the month (e.g. February 30).
+func NewDate(year, month, day int) (Date, error) {
+	if month < 1 || month > 12 {
+		return 0, Errf("invalid month: %d", month)
+	}
+	if day < 1 || day > daysInMonth(year, month) {
+		return 0, Errf("invalid day: %d", day)
+	}
+	return Date(daysFromCivil(year, month, day)), nil
+}
+
+// ParseCivilDate parses a "YYYY-MM-DD" string into a Date with the same validation as ParseDate.
+func ParseCivilDate(s string) (Date, error) {
+	nano, err := parseLayout(s, "YYYY-MM-DD", utcOffset)
+	if err != nil {
+		return 0, err
+	}
+	return DateOf(nano), nil
+}
+
+// DateOf returns the UTC calendar date of a UnixNano timestamp.
+// For another zone, shift first: DateOf(nano + int64(tp.Offset(nano))*1e9).
+func DateOf(nano int64) Date {
+	days, _ := splitNano(nano)
+	return Date(days)
+}
+
+// UnixNano returns the UnixNano timestamp of midnight UTC at the start of the date.
+func (d Date) UnixNano() int64 {
+	return int64(d) * nanosPerDay
+}
+
+// Year returns the year of the date.
+func (d Date) Year() int {
+	year, _, _ := civilFromDays(int64(d))
+	return year
+}
+
+// Month returns the month of the date (1-12).
+func (d Date) Month() int {
+	_, month, _ := civilFromDays(int64(d))
+	return month
+}
+
+// Day returns the day of the month (1-31).
+func (d Date) Day() int {
+	_, _, day := civilFromDays(int64(d))
+	return day
+}
+
+// Weekday returns the day of the week.
+func (d Date) Weekday() Weekday {
+	return weekdayFromDays(int64(d))
+}
+
+// AddDays returns the date n days later (earlier when n is negative).
+func (d Date) AddDays(n int) Date {
+	return d + Date(n)
+}
+
+// Compare returns -1 if d is before other, 0 if they are equal and +1 if d is after other.
+func (d Date) Compare(other Date) int {
+	switch {
+	case d < other:
+		return -1
+	case d > other:
+		return 1
+	}
+	return 0
+}
+
+// String returns the date as "YYYY-MM-DD".
+func (d Date) String() string {
+	year, month, day := civilFromDays(int64(d))
+	buf := appendInt(make([]byte, 0, 10), year, 4)
+	buf = append(buf, '-')
+	buf = appendInt(buf, month, 2)
+	buf = append(buf, '-')
+	return string(appendInt(buf, day, 2))
+}
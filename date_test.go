@@ -0,0 +1,93 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+func TestDate(t *testing.T) {
+	d, err := tinytime.NewDate(2024, 2, 29)
+	if err != nil {
+		t.Fatalf("NewDate(2024, 2, 29) failed: %v", err)
+	}
+
+	if d.Year() != 2024 || d.Month() != 2 || d.Day() != 29 {
+		t.Errorf("Date fields = %d-%d-%d; want 2024-2-29", d.Year(), d.Month(), d.Day())
+	}
+	if d.Weekday() != tinytime.Thursday {
+		t.Errorf("Weekday() = %s; want Thursday", d.Weekday())
+	}
+	if d.String() != "2024-02-29" {
+		t.Errorf("String() = %s; want 2024-02-29", d.String())
+	}
+	if got := d.AddDays(1).String(); got != "2024-03-01" {
+		t.Errorf("AddDays(1) = %s; want 2024-03-01", got)
+	}
+	if got := d.AddDays(-60).String(); got != "2023-12-31" {
+		t.Errorf("AddDays(-60) = %s; want 2023-12-31", got)
+	}
+
+	if d.Compare(d.AddDays(1)) != -1 || d.Compare(d) != 0 || d.AddDays(1).Compare(d) != 1 {
+		t.Error("Compare returned unexpected ordering")
+	}
+
+	// Epoch and before
+	if tinytime.Date(0).String() != "1970-01-01" || tinytime.Date(-1).String() != "1969-12-31" {
+		t.Errorf("Date(0), Date(-1) = %s, %s", tinytime.Date(0), tinytime.Date(-1))
+	}
+
+	for _, invalid := range [][3]int{{2023, 2, 29}, {2024, 4, 31}, {2024, 13, 1}, {2024, 0, 10}, {2024, 1, 0}} {
+		if _, err := tinytime.NewDate(invalid[0], invalid[1], invalid[2]); err == nil {
+			t.Errorf("NewDate(%v) should return error", invalid)
+		}
+	}
+}
+
+func TestDate_UnixNano(t *testing.T) {
+	const midnight = int64(1705276800000000000) // 2024-01-15 00:00:00 UTC
+
+	d := tinytime.DateOf(midnight + 8*3600000000000)
+	if d.String() != "2024-01-15" {
+		t.Errorf("DateOf() = %s; want 2024-01-15", d)
+	}
+	if d.UnixNano() != midnight {
+		t.Errorf("UnixNano() = %d; want %d", d.UnixNano(), midnight)
+	}
+	if tinytime.DateOf(-1).String() != "1969-12-31" {
+		t.Errorf("DateOf(-1) = %s; want 1969-12-31", tinytime.DateOf(-1))
+	}
+}
+
+func TestParseCivilDate(t *testing.T) {
+	d, err := tinytime.ParseCivilDate("2024-01-15")
+	if err != nil {
+		t.Fatalf("ParseCivilDate(2024-01-15) failed: %v", err)
+	}
+	if d.String() != "2024-01-15" {
+		t.Errorf("ParseCivilDate(2024-01-15) = %s", d)
+	}
+
+	// Same rejections as ParseDate
+	for _, invalid := range []string{"invalid", "2024-02-30", "2024-1-1", "20240-1-15", "2024/01/15", ""} {
+		if _, err := tinytime.ParseCivilDate(invalid); err == nil {
+			t.Errorf("ParseCivilDate(%q) should return error", invalid)
+		}
+	}
+}
+
+func TestDate_ProviderFormatting(t *testing.T) {
+	d, _ := tinytime.NewDate(2024, 1, 15)
+
+	// A Date ignores the provider's zone: it has no time to shift
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone failed: %v", err)
+	}
+	if got := tp.FormatDate(d); got != "2024-01-15" {
+		t.Errorf("FormatDate(Date) = %s; want 2024-01-15", got)
+	}
+	if got := tp.Format(d, "dddd DD/MM/YYYY"); got != "Monday 15/01/2024" {
+		t.Errorf("Format(Date) = %s; want Monday 15/01/2024", got)
+	}
+}
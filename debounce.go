@@ -0,0 +1,146 @@
+package tinytime
+
+import "sync"
+
+// Edge selects when a Debounce or Throttle Limiter invokes its function. Combine with |.
+type Edge uint8
+
+const (
+	Leading  Edge = 1 << iota // invoke on the first call of a burst
+	Trailing                  // invoke after the burst (Debounce) or at the end of the window (Throttle)
+)
+
+// Limiter wraps a function so that bursts of Call invocations run it at a controlled rate.
+// It is created by Debounce or Throttle and is safe for concurrent use.
+type Limiter struct {
+	tp       TimeProvider
+	delay    int
+	f        func()
+	edges    Edge
+	throttle bool
+
+	mu      sync.Mutex
+	timer   Timer
+	gen     int  // identifies the current timer; callbacks of older timers are ignored
+	pending bool // a trailing invocation is due when the timer fires
+}
+
+// Debounce returns a Limiter that runs f once calls stop arriving for the given milliseconds,
+// e.g. a search request after the user stops typing. Every Call restarts the wait.
+// By default f runs on the Trailing edge; pass Leading (or Leading|Trailing) to also or only
+// run it on the first call of a burst.
+func Debounce(tp TimeProvider, milliseconds int, f func(), edges ...Edge) *Limiter {
+	return newLimiter(tp, milliseconds, f, false, Trailing, edges)
+}
+
+// Throttle returns a Limiter that runs f at most once per window of the given milliseconds,
+// e.g. an autosave while the user keeps typing. By default f runs on the Leading edge (the first
+// call) and on the Trailing edge (once at the end of the window if more calls arrived);
+// a trailing run opens a new window.
+func Throttle(tp TimeProvider, milliseconds int, f func(), edges ...Edge) *Limiter {
+	return newLimiter(tp, milliseconds, f, true, Leading|Trailing, edges)
+}
+
+func newLimiter(tp TimeProvider, milliseconds int, f func(), throttle bool, defaults Edge, edges []Edge) *Limiter {
+	l := &Limiter{tp: tp, delay: milliseconds, f: f, throttle: throttle, edges: defaults}
+	if len(edges) > 0 {
+		l.edges = 0
+		for _, e := range edges {
+			l.edges |= e
+		}
+	}
+	return l
+}
+
+// Call requests an invocation of the wrapped function.
+func (l *Limiter) Call() {
+	l.mu.Lock()
+	if l.timer != nil && l.timer.Active() {
+		// Inside a burst: remember the trailing run and, when debouncing, restart the wait
+		if l.edges&Trailing != 0 {
+			l.pending = true
+		}
+		if !l.throttle {
+			l.timer.Reset(l.delay)
+		}
+		l.mu.Unlock()
+		return
+	}
+
+	leading := l.edges&Leading != 0
+	l.pending = !leading && l.edges&Trailing != 0
+	l.arm()
+	l.mu.Unlock()
+
+	if leading {
+		l.invoke()
+	}
+}
+
+// arm starts a new timer for the current burst. Caller must hold l.mu.
+// A fresh timer per burst lets a callback that already left its timer recognize itself as stale.
+func (l *Limiter) arm() {
+	l.gen++
+	gen := l.gen
+	l.timer = l.tp.AfterFunc(l.delay, func() { l.fire(gen) })
+}
+
+// fire ends the wait of timer gen, running the trailing invocation if one is due.
+func (l *Limiter) fire(gen int) {
+	l.mu.Lock()
+	if gen != l.gen || !l.pending {
+		l.mu.Unlock()
+		return
+	}
+	l.pending = false
+	if l.throttle {
+		l.arm() // calls right after a trailing run wait for the next window
+	}
+	l.mu.Unlock()
+
+	l.invoke()
+}
+
+func (l *Limiter) invoke() {
+	if l.f != nil {
+		l.f()
+	}
+}
+
+// Cancel drops a pending trailing invocation and ends the current burst,
+// so the next Call starts a new one.
+func (l *Limiter) Cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stop()
+}
+
+// Flush runs a pending trailing invocation immediately instead of waiting, and ends the burst.
+// Returns false if no invocation was pending.
+func (l *Limiter) Flush() bool {
+	l.mu.Lock()
+	pending := l.pending
+	l.stop()
+	l.mu.Unlock()
+
+	if pending {
+		l.invoke()
+	}
+	return pending
+}
+
+// Pending reports whether a trailing invocation is waiting.
+func (l *Limiter) Pending() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pending
+}
+
+// stop cancels the timer and any pending invocation. Caller must hold l.mu.
+func (l *Limiter) stop() {
+	if l.timer != nil {
+		l.timer.Stop()
+	}
+	l.gen++
+	l.pending = false
+}
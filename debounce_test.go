@@ -0,0 +1,152 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// callLog records the fake-clock offsets (in ms from start) at which a limited function ran.
+type callLog struct {
+	tp    *tinytime.FakeTimeProvider
+	start int64
+	at    []int64
+}
+
+func newCallLog(tp *tinytime.FakeTimeProvider) *callLog {
+	return &callLog{tp: tp, start: tp.UnixNano()}
+}
+
+func (c *callLog) record() {
+	c.at = append(c.at, (c.tp.UnixNano()-c.start)/1000000)
+}
+
+func (c *callLog) check(t *testing.T, name string, want ...int64) {
+	t.Helper()
+	if len(c.at) != len(want) {
+		t.Errorf("%s: ran at %v ms; want %v", name, c.at, want)
+		return
+	}
+	for i := range want {
+		if c.at[i] != want[i] {
+			t.Errorf("%s: ran at %v ms; want %v", name, c.at, want)
+			return
+		}
+	}
+}
+
+// callAt moves the fake clock to each offset (ms from the log's start) and calls l.
+func (c *callLog) callAt(l *tinytime.Limiter, offsets ...int64) {
+	for _, ms := range offsets {
+		c.tp.Set(c.start + ms*1000000)
+		l.Call()
+	}
+}
+
+// waitUntil moves the fake clock to the offset (ms from the log's start).
+func (c *callLog) waitUntil(ms int64) {
+	c.tp.Set(c.start + ms*1000000)
+}
+
+func TestDebounce(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	log := newCallLog(tp)
+	d := tinytime.Debounce(tp, 100, log.record)
+
+	// Typing burst: each call restarts the 100ms wait
+	log.callAt(d, 0, 50, 120, 200)
+	if !d.Pending() {
+		t.Error("Pending() should be true during a burst")
+	}
+	log.waitUntil(299)
+	log.check(t, "before quiet period")
+	log.waitUntil(300)
+	log.check(t, "trailing", 300)
+
+	// A second burst runs once more
+	log.callAt(d, 500, 550)
+	log.waitUntil(1000)
+	log.check(t, "second burst", 300, 650)
+	if d.Pending() {
+		t.Error("Pending() should be false after the trailing run")
+	}
+}
+
+func TestDebounce_Leading(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	log := newCallLog(tp)
+	d := tinytime.Debounce(tp, 100, log.record, tinytime.Leading)
+
+	log.callAt(d, 0, 50, 120, 200)
+	log.waitUntil(1000)
+	log.check(t, "leading only", 0)
+
+	// After a quiet period the next call runs immediately again
+	log.callAt(d, 1000)
+	log.check(t, "next burst", 0, 1000)
+
+	log = newCallLog(tp)
+	both := tinytime.Debounce(tp, 100, log.record, tinytime.Leading|tinytime.Trailing)
+	log.callAt(both, 0, 50)
+	log.waitUntil(2000)
+	log.check(t, "leading and trailing", 0, 150)
+
+	// A single call runs only on the leading edge
+	log.callAt(both, 3000)
+	log.waitUntil(4000)
+	log.check(t, "single call", 0, 150, 3000)
+}
+
+func TestThrottle(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	log := newCallLog(tp)
+	th := tinytime.Throttle(tp, 100, log.record)
+
+	// Calls every 30ms: leading at 0, trailing at the end of each window
+	log.callAt(th, 0, 30, 60, 90, 120, 150, 180, 210)
+	log.waitUntil(1000)
+	log.check(t, "leading and trailing", 0, 100, 200, 300)
+
+	log = newCallLog(tp)
+	leading := tinytime.Throttle(tp, 100, log.record, tinytime.Leading)
+	log.callAt(leading, 0, 30, 60, 90, 120, 150)
+	log.waitUntil(2000)
+	log.check(t, "leading only", 0, 120)
+
+	log = newCallLog(tp)
+	trailing := tinytime.Throttle(tp, 100, log.record, tinytime.Trailing)
+	log.callAt(trailing, 0, 30, 60, 90, 120, 150)
+	log.waitUntil(2000)
+	log.check(t, "trailing only", 100, 200)
+}
+
+func TestLimiter_CancelFlush(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	log := newCallLog(tp)
+	d := tinytime.Debounce(tp, 100, log.record)
+
+	log.callAt(d, 0, 50)
+	d.Cancel()
+	log.waitUntil(500)
+	log.check(t, "cancel")
+	if tp.Pending() != 0 {
+		t.Errorf("Pending timers after Cancel = %d; want 0", tp.Pending())
+	}
+
+	log.callAt(d, 600)
+	if !d.Flush() {
+		t.Error("Flush() should return true with a pending run")
+	}
+	log.check(t, "flush", 600)
+	log.waitUntil(1000)
+	log.check(t, "no run after flush", 600)
+	if d.Flush() {
+		t.Error("Flush() should return false without a pending run")
+	}
+
+	// Nil functions are allowed, like AfterFunc
+	nilLimiter := tinytime.Throttle(tp, 100, nil)
+	nilLimiter.Call()
+	nilLimiter.Call()
+	tp.Advance(200)
+}
@@ -0,0 +1,111 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	. "github.com/cdvelop/tinystring"
+	"github.com/cdvelop/tinytime"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  tinytime.Duration
+	}{
+		// Go syntax
+		{"1h30m", 90 * tinytime.Minute},
+		{"-1.5h", -90 * tinytime.Minute},
+		{"300ms", 300 * tinytime.Millisecond},
+		{"2h45m30.5s", 2*tinytime.Hour + 45*tinytime.Minute + 30500*tinytime.Millisecond},
+		{"1us", tinytime.Microsecond},
+		{"1µs", tinytime.Microsecond},
+		{"15ns", 15},
+		{"0", 0},
+		{"+5m", 5 * tinytime.Minute},
+		// ISO 8601
+		{"PT1H30M", 90 * tinytime.Minute},
+		{"P1DT2H", 26 * tinytime.Hour},
+		{"P2W", 14 * 24 * tinytime.Hour},
+		{"PT0.5S", 500 * tinytime.Millisecond},
+		{"PT1,5H", 90 * tinytime.Minute},
+		{"-PT5M", -5 * tinytime.Minute},
+		{"pt45s", 45 * tinytime.Second},
+		// Words
+		{"90 minutes", 90 * tinytime.Minute},
+		{"1 hour 30 minutes", 90 * tinytime.Minute},
+		{"1 hour, 30 minutes", 90 * tinytime.Minute},
+		{"2 Hours", 2 * tinytime.Hour},
+		{"1 hora 30 minutos", 90 * tinytime.Minute},
+		{"2 días", 48 * tinytime.Hour},
+		{"2 Tage 4 Stunden", 52 * tinytime.Hour},
+		{"1.5 hrs", 90 * tinytime.Minute},
+		{"10 sec", 10 * tinytime.Second},
+		{"1 week", 7 * 24 * tinytime.Hour},
+	}
+	for _, tt := range tests {
+		got, err := tinytime.ParseDuration(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDuration(%q) = %d, %v; want %d", tt.input, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{
+		"", "-", "1", "1x", "h", "1h30", "P", "PT", "P1Y", "P1M", "PT1D", "P1H", "P1DT", "PT1H1H2",
+		"90 parsecs", "minutes 90", "1 hour 30", "9999999999999999999h", "10000000h",
+	} {
+		if _, err := tinytime.ParseDuration(invalid); err == nil {
+			t.Errorf("ParseDuration(%q) should return error", invalid)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	d := 90 * tinytime.Minute
+
+	tests := []struct {
+		d     tinytime.Duration
+		style tinytime.DurationStyle
+		want  string
+	}{
+		{d, tinytime.DurationCompact, "1h 30m"},
+		{26*tinytime.Hour + 5*tinytime.Second, tinytime.DurationCompact, "1d 2h 5s"},
+		{250 * tinytime.Millisecond, tinytime.DurationCompact, "250ms"},
+		{1500 * tinytime.Nanosecond, tinytime.DurationCompact, "1µs"},
+		{15, tinytime.DurationCompact, "15ns"},
+		{0, tinytime.DurationCompact, "0s"},
+		{-d, tinytime.DurationCompact, "-1h 30m"},
+		{d, tinytime.DurationClock, "01:30:00"},
+		{36*tinytime.Hour + 61*tinytime.Second, tinytime.DurationClock, "36:01:01"},
+		{-5 * tinytime.Minute, tinytime.DurationClock, "-00:05:00"},
+		{d, tinytime.DurationLong, "1 hour 30 minutes"},
+		{49*tinytime.Hour + tinytime.Second, tinytime.DurationLong, "2 days 1 hour 1 second"},
+		{500 * tinytime.Millisecond, tinytime.DurationLong, "0 seconds"},
+	}
+	for _, tt := range tests {
+		if got := tinytime.FormatDuration(tt.d, tt.style); got != tt.want {
+			t.Errorf("FormatDuration(%d, %d) = %q; want %q", int64(tt.d), tt.style, got, tt.want)
+		}
+	}
+
+	if got := d.String(); got != "1h 30m" {
+		t.Errorf("String() = %q; want %q", got, "1h 30m")
+	}
+
+	OutLang(ES)
+	defer OutLang(EN)
+	if got := tinytime.FormatDuration(d, tinytime.DurationLong); got != "1 hora 30 minutos" {
+		t.Errorf("FormatDuration(ES) = %q; want %q", got, "1 hora 30 minutos")
+	}
+
+	OutLang(DE)
+	if got := tinytime.FormatDuration(52*tinytime.Hour, tinytime.DurationLong); got != "2 Tage 4 Stunden" {
+		t.Errorf("FormatDuration(DE) = %q; want %q", got, "2 Tage 4 Stunden")
+	}
+
+	OutLang(EN)
+	d = 26*tinytime.Hour + 90*tinytime.Second
+	text := tinytime.FormatDuration(d, tinytime.DurationLong)
+	if back, err := tinytime.ParseDuration(text); err != nil || back != d {
+		t.Errorf("ParseDuration(%q) = %d, %v; want %d", text, back, err, d)
+	}
+}
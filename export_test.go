@@ -10,3 +10,17 @@ func FireTimer(t Timer) {
 		wt.fire()
 	}
 }
+
+// FireTicker triggers one tick of the ticker callback manually for testing purposes.
+func FireTicker(t Ticker) {
+	if wt, ok := t.(*wasmTicker); ok {
+		wt.tick()
+	}
+}
+
+// LimiterTimer returns the timer of the current Debounce or Throttle burst.
+func LimiterTimer(l *Limiter) Timer {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.timer
+}
@@ -0,0 +1,195 @@
+package tinytime
+
+import "sync"
+
+// FakeTimeProvider is a deterministic TimeProvider for tests.
+// Its clock only moves through Set and Advance, which synchronously fire due AfterFunc timers
+// in deadline order. Formatting and parsing are delegated to the embedded TimeProvider.
+type FakeTimeProvider struct {
+	TimeProvider
+
+	mu     sync.Mutex
+	now    int64
+	seq    int
+	timers []*fakeTimer // active timers, unordered
+}
+
+// NewFakeTimeProvider returns a FakeTimeProvider in UTC whose clock starts at the given UnixNano.
+func NewFakeTimeProvider(nano int64) *FakeTimeProvider {
+	return &FakeTimeProvider{
+		TimeProvider: NewTimeProvider(),
+		now:          nano,
+	}
+}
+
+func (fp *FakeTimeProvider) UnixNano() int64 {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return fp.now
+}
+
+// MonotonicNano follows the fake clock, so Stopwatch measurements advance with Set and Advance.
+func (fp *FakeTimeProvider) MonotonicNano() int64 {
+	return fp.UnixNano()
+}
+
+func (fp *FakeTimeProvider) IsToday(nano int64) bool {
+	return fp.FormatDate(nano) == fp.FormatDate(fp.UnixNano())
+}
+
+func (fp *FakeTimeProvider) IsPast(nano int64) bool {
+	return nano < fp.UnixNano()
+}
+
+func (fp *FakeTimeProvider) IsFuture(nano int64) bool {
+	return nano > fp.UnixNano()
+}
+
+func (fp *FakeTimeProvider) FormatRelative(nano int64) string {
+	return formatRelative(nano, fp.UnixNano())
+}
+
+func (fp *FakeTimeProvider) AfterFunc(milliseconds int, f func()) Timer {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	ft := &fakeTimer{
+		fp:       fp,
+		deadline: fp.now + int64(milliseconds)*1000000,
+		f:        f,
+	}
+	fp.schedule(ft)
+	return ft
+}
+
+func (fp *FakeTimeProvider) Every(milliseconds int, f func()) Ticker {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	period := int64(tickerInterval(milliseconds)) * 1000000
+	ft := &fakeTimer{
+		fp:       fp,
+		deadline: fp.now + period,
+		period:   period,
+		f:        f,
+	}
+	fp.schedule(ft)
+	return ft
+}
+
+// Set moves the clock to the given UnixNano, firing every timer whose deadline is reached.
+// Timers fire in deadline order (creation order on ties) with the clock set to their deadline,
+// so callbacks observe the time they were scheduled for. Timers created by a callback also fire
+// if they fall due before nano, and tickers fire once per elapsed period.
+// Moving the clock backwards fires nothing.
+func (fp *FakeTimeProvider) Set(nano int64) {
+	for {
+		fp.mu.Lock()
+		ft := fp.nextDue(nano)
+		if ft == nil {
+			fp.now = nano
+			fp.mu.Unlock()
+			return
+		}
+		if ft.deadline > fp.now {
+			fp.now = ft.deadline
+		}
+		fp.remove(ft)
+		if ft.period > 0 { // tickers re-arm for their next period
+			ft.deadline += ft.period
+			fp.schedule(ft)
+		}
+		f := ft.f
+		fp.mu.Unlock()
+
+		if f != nil {
+			f()
+		}
+	}
+}
+
+// Advance moves the clock forward by the given milliseconds. See Set.
+func (fp *FakeTimeProvider) Advance(milliseconds int) {
+	fp.Set(fp.UnixNano() + int64(milliseconds)*1000000)
+}
+
+// Pending returns the number of timers that have not fired or been stopped.
+func (fp *FakeTimeProvider) Pending() int {
+	fp.mu.Lock()
+	defer fp.mu.Unlock()
+	return len(fp.timers)
+}
+
+// schedule registers ft as active. Caller must hold fp.mu.
+func (fp *FakeTimeProvider) schedule(ft *fakeTimer) {
+	fp.seq++
+	ft.seq = fp.seq
+	ft.active = true
+	fp.timers = append(fp.timers, ft)
+}
+
+// nextDue returns the earliest active timer due at or before nano. Caller must hold fp.mu.
+func (fp *FakeTimeProvider) nextDue(nano int64) *fakeTimer {
+	var next *fakeTimer
+	for _, ft := range fp.timers {
+		if ft.deadline > nano {
+			continue
+		}
+		if next == nil || ft.deadline < next.deadline || (ft.deadline == next.deadline && ft.seq < next.seq) {
+			next = ft
+		}
+	}
+	return next
+}
+
+// remove deactivates ft and drops it from the active list. Caller must hold fp.mu.
+func (fp *FakeTimeProvider) remove(ft *fakeTimer) {
+	ft.active = false
+	for i, t := range fp.timers {
+		if t == ft {
+			fp.timers = append(fp.timers[:i], fp.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// fakeTimer implements Timer and Ticker for FakeTimeProvider
+type fakeTimer struct {
+	fp       *FakeTimeProvider
+	deadline int64
+	period   int64 // nanoseconds between ticks; 0 for one-shot timers
+	seq      int
+	active   bool
+	f        func()
+}
+
+func (ft *fakeTimer) Stop() bool {
+	ft.fp.mu.Lock()
+	defer ft.fp.mu.Unlock()
+	if !ft.active {
+		return false
+	}
+	ft.fp.remove(ft)
+	return true
+}
+
+func (ft *fakeTimer) Reset(milliseconds int) bool {
+	ft.fp.mu.Lock()
+	defer ft.fp.mu.Unlock()
+	wasActive := ft.active
+	if wasActive {
+		ft.fp.remove(ft)
+	}
+	delay := int64(milliseconds) * 1000000
+	if ft.period > 0 {
+		delay = int64(tickerInterval(milliseconds)) * 1000000
+		ft.period = delay
+	}
+	ft.deadline = ft.fp.now + delay
+	ft.fp.schedule(ft)
+	return wasActive
+}
+
+func (ft *fakeTimer) Active() bool {
+	ft.fp.mu.Lock()
+	defer ft.fp.mu.Unlock()
+	return ft.active
+}
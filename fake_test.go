@@ -0,0 +1,251 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// Fake clock tests have no build tags: they are deterministic and run unchanged
+// under both the stdlib and WASM builds.
+
+func TestAllSharedFake(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(1705307400000000000) // 2024-01-15 08:30:00 UTC
+
+	t.Run("UnixNano", func(t *testing.T) { UnixNanoShared(t, tp) })
+	t.Run("FormatDate", func(t *testing.T) { FormatDateShared(t, tp) })
+	t.Run("FormatTime", func(t *testing.T) { FormatTimeShared(t, tp) })
+	t.Run("FormatDateTime", func(t *testing.T) { FormatDateTimeShared(t, tp) })
+	t.Run("FormatDateTimeShort", func(t *testing.T) { FormatDateTimeShortShared(t, tp) })
+	t.Run("ParseDate", func(t *testing.T) { ParseDateShared(t, tp) })
+	t.Run("ParseTime", func(t *testing.T) { ParseTimeShared(t, tp) })
+	t.Run("ParseTimeSeconds", func(t *testing.T) { ParseTimeSecondsShared(t, tp) })
+	t.Run("ParseDateTime", func(t *testing.T) { ParseDateTimeShared(t, tp) })
+	t.Run("IsToday", func(t *testing.T) { IsTodayShared(t, tp) })
+	t.Run("IsPast", func(t *testing.T) { IsPastShared(t, tp) })
+	t.Run("IsFuture", func(t *testing.T) { IsFutureShared(t, tp) })
+	t.Run("DaysBetween", func(t *testing.T) { DaysBetweenShared(t, tp) })
+	t.Run("Format", func(t *testing.T) { FormatShared(t, tp) })
+	t.Run("Parse", func(t *testing.T) { ParseShared(t, tp) })
+	t.Run("RFC3339", func(t *testing.T) { RFC3339Shared(t, tp) })
+	t.Run("FormatRelative", func(t *testing.T) { FormatRelativeShared(t, tp) })
+	t.Run("AddDate", func(t *testing.T) { AddDateShared(t, tp) })
+	t.Run("PeriodBounds", func(t *testing.T) { PeriodBoundsShared(t, tp) })
+	t.Run("CalendarDiff", func(t *testing.T) { CalendarDiffShared(t, tp) })
+	t.Run("ISOWeek", func(t *testing.T) { ISOWeekShared(t, tp) })
+}
+
+func TestFakeTimeProvider_Clock(t *testing.T) {
+	start := int64(1705307400000000000) // 2024-01-15 08:30:00 UTC
+	tp := tinytime.NewFakeTimeProvider(start)
+
+	if tp.UnixNano() != start {
+		t.Errorf("UnixNano() = %d; want %d", tp.UnixNano(), start)
+	}
+
+	tp.Advance(1500)
+	if tp.UnixNano() != start+1500000000 {
+		t.Errorf("UnixNano() after Advance(1500) = %d; want %d", tp.UnixNano(), start+1500000000)
+	}
+
+	tp.Set(start)
+	if tp.UnixNano() != start {
+		t.Errorf("UnixNano() after Set = %d; want %d", tp.UnixNano(), start)
+	}
+
+	// 15:30 UTC is still 2024-01-15, 2024-01-16 00:00 is not
+	if !tp.IsToday(start + 7*3600*1000000000) {
+		t.Error("IsToday(same day) should return true")
+	}
+	if tp.IsToday(1705363200000000000) {
+		t.Error("IsToday(next day) should return false")
+	}
+
+	if !tp.IsPast(start-1) || tp.IsPast(start) {
+		t.Error("IsPast should compare against the fake clock")
+	}
+	if !tp.IsFuture(start+1) || tp.IsFuture(start) {
+		t.Error("IsFuture should compare against the fake clock")
+	}
+
+	t.Log("FakeTimeProvider clock tests passed")
+}
+
+func TestFakeTimeProvider_AfterFuncOrder(t *testing.T) {
+	start := int64(1705307400000000000)
+	tp := tinytime.NewFakeTimeProvider(start)
+
+	var fired []string
+	var firedAt []int64
+	record := func(name string) func() {
+		return func() {
+			fired = append(fired, name)
+			firedAt = append(firedAt, tp.UnixNano())
+		}
+	}
+
+	tp.AfterFunc(300, record("c"))
+	tp.AfterFunc(100, record("a"))
+	tp.AfterFunc(200, record("b1"))
+	tp.AfterFunc(200, record("b2"))
+	tp.AfterFunc(1000, record("late"))
+
+	tp.Advance(99)
+	if len(fired) != 0 {
+		t.Fatalf("no timer should fire before its deadline, got %v", fired)
+	}
+
+	tp.Advance(401) // now start+500ms
+	want := []string{"a", "b1", "b2", "c"}
+	if len(fired) != len(want) {
+		t.Fatalf("fired = %v; want %v", fired, want)
+	}
+	for i := range want {
+		if fired[i] != want[i] {
+			t.Errorf("fired[%d] = %s; want %s", i, fired[i], want[i])
+		}
+	}
+
+	// Callbacks observe the clock at their own deadline
+	wantAt := []int64{100, 200, 200, 300}
+	for i, ms := range wantAt {
+		if firedAt[i] != start+ms*1000000 {
+			t.Errorf("firedAt[%d] = %d; want %d", i, firedAt[i], start+ms*1000000)
+		}
+	}
+
+	if tp.UnixNano() != start+500000000 {
+		t.Errorf("UnixNano() after Advance = %d; want %d", tp.UnixNano(), start+500000000)
+	}
+	if tp.Pending() != 1 {
+		t.Errorf("Pending() = %d; want 1", tp.Pending())
+	}
+
+	t.Log("FakeTimeProvider AfterFunc order tests passed")
+}
+
+func TestFakeTimeProvider_Stop(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	timer, executed := AfterFuncStopSetup(tp)
+
+	if !timer.Stop() {
+		t.Error("Stop() should return true for active timer")
+	}
+	if timer.Stop() {
+		t.Error("Stop() should return false for already-stopped timer")
+	}
+
+	tp.Advance(200)
+	AfterFuncStopVerify(t, executed)
+
+	// Stop after firing reports inactive
+	fired := tp.AfterFunc(10, nil) // nil callback must not panic
+	tp.Advance(10)
+	if fired.Stop() {
+		t.Error("Stop() should return false after timer fired")
+	}
+	if tp.Pending() != 0 {
+		t.Errorf("Pending() = %d; want 0", tp.Pending())
+	}
+}
+
+func TestFakeTimeProvider_NestedTimers(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	count := 0
+
+	// A callback that re-arms itself, like a hand-rolled polling loop
+	var tick func()
+	tick = func() {
+		count++
+		tp.AfterFunc(100, tick)
+	}
+	tp.AfterFunc(100, tick)
+
+	tp.Advance(550)
+	if count != 5 {
+		t.Errorf("re-armed timer fired %d times; want 5", count)
+	}
+	if tp.Pending() != 1 {
+		t.Errorf("Pending() = %d; want 1", tp.Pending())
+	}
+}
+
+func TestFakeTimeProvider_Every(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	count := 0
+
+	ticker := tp.Every(100, func() { count++ })
+
+	tp.Advance(350)
+	if count != 3 {
+		t.Errorf("ticker fired %d times in 350ms; want 3", count)
+	}
+
+	// Reset restarts the period from the current fake time (350ms)
+	if !ticker.Reset(200) {
+		t.Error("Reset() should return true for active ticker")
+	}
+	tp.Advance(199)
+	if count != 3 {
+		t.Errorf("ticker fired early after Reset: %d", count)
+	}
+	tp.Advance(201) // now 750ms: ticks at 550 and 750
+	if count != 5 {
+		t.Errorf("ticker fired %d times after Reset; want 5", count)
+	}
+
+	if !ticker.Stop() {
+		t.Error("Stop() should return true for active ticker")
+	}
+	tp.Advance(1000)
+	if count != 5 {
+		t.Errorf("ticker fired after Stop(): %d", count)
+	}
+	if tp.Pending() != 0 {
+		t.Errorf("Pending() = %d; want 0", tp.Pending())
+	}
+}
+
+func TestFakeTimeProvider_ResetActive(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	count := 0
+
+	timer := tp.AfterFunc(100, func() { count++ })
+	if !timer.Active() {
+		t.Error("Active() should return true for armed timer")
+	}
+
+	tp.Advance(80)
+	if !timer.Reset(100) { // deadline moves to 180ms
+		t.Error("Reset() should return true for active timer")
+	}
+	tp.Advance(80)
+	if count != 0 {
+		t.Errorf("callback fired before reset deadline: %d", count)
+	}
+	tp.Advance(20)
+	if count != 1 {
+		t.Errorf("count = %d; want 1", count)
+	}
+	if timer.Active() {
+		t.Error("Active() should return false after timer fired")
+	}
+
+	if timer.Reset(50) {
+		t.Error("Reset() should return false for fired timer")
+	}
+	tp.Advance(50)
+	if count != 2 {
+		t.Errorf("count after re-arm = %d; want 2", count)
+	}
+}
+
+func TestFakeTimeProvider_FormatRelative(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(1705307400000000000)
+	event := tp.UnixNano()
+
+	tp.Advance(2 * 24 * 3600 * 1000)
+	if got := tp.FormatRelative(event); got != "2 days ago" {
+		t.Errorf("FormatRelative(event) = %q; want %q", got, "2 days ago")
+	}
+}
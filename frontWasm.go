@@ -12,15 +12,147 @@ import (
 // timeClient implements TimeProvider for WASM/JS environments using the JavaScript Date API.
 type timeClient struct {
 	dateCtor js.Value
+	zone     string
+	zoneFmt  js.Value // Intl.DateTimeFormat bound to zone; undefined for UTC
 }
 
 // NewTimeProvider returns the correct implementation for WASM.
 func NewTimeProvider() TimeProvider {
 	return &timeClient{
 		dateCtor: js.Global().Get("Date"),
+		zone:     "UTC",
 	}
 }
 
+// NewTimeProviderInZone returns a TimeProvider bound to the given IANA time zone
+// (e.g. "America/Santiago") using Intl.DateTimeFormat. An empty zone or "UTC" behaves like NewTimeProvider.
+// The name must be spelled as in the tz database, and Zone reports it as passed, like timeServer.
+func NewTimeProviderInZone(zone string) (TimeProvider, error) {
+	tc := &timeClient{
+		dateCtor: js.Global().Get("Date"),
+		zone:     "UTC",
+	}
+	if zone == "" || zone == "UTC" {
+		return tc, nil
+	}
+
+	zoneFmt, ok := newZoneFormat(zone)
+	if !ok || !zoneSpelledAsIANA(zone, zoneFmt.Call("resolvedOptions").Get("timeZone").String()) {
+		return nil, Errf("invalid time zone: %s", zone)
+	}
+	tc.zone = zone
+	tc.zoneFmt = zoneFmt
+	return tc, nil
+}
+
+// zoneSpelledAsIANA reports whether zone is spelled as time.LoadLocation requires, given the name
+// Intl resolved it to. Intl ignores case, so a name that only resolves after changing its case
+// ("america/santiago", "utc") is rejected. An alias Intl renames (Asia/Kolkata to Asia/Calcutta)
+// must start every part with a capital letter, as all tz database names do.
+func zoneSpelledAsIANA(zone, resolved string) bool {
+	if zone == resolved {
+		return true
+	}
+	if equalFold(zone, resolved) {
+		return false
+	}
+	for _, part := range Convert(zone).Split("/") {
+		if part == "" || part[0] < 'A' || part[0] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// newZoneFormat creates an Intl.DateTimeFormat reporting numeric 24h fields in the given zone.
+// Returns false if the browser rejects the zone (Intl throws a RangeError).
+func newZoneFormat(zone string) (zoneFmt js.Value, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	intl := js.Global().Get("Intl")
+	if intl.IsUndefined() {
+		return js.Undefined(), false
+	}
+	zoneFmt = intl.Get("DateTimeFormat").New("en-US", map[string]any{
+		"timeZone":  zone,
+		"hourCycle": "h23",
+		"year":      "numeric",
+		"month":     "numeric",
+		"day":       "numeric",
+		"hour":      "numeric",
+		"minute":    "numeric",
+		"second":    "numeric",
+	})
+	return zoneFmt, true
+}
+
+func (tc *timeClient) Zone() string {
+	return tc.zone
+}
+
+func (tc *timeClient) Offset(nano int64) int {
+	if tc.zoneFmt.IsUndefined() {
+		return 0
+	}
+	// Truncate to whole seconds (floor) so the wall-clock fields and the instant line up
+	sec := nano / 1000000000
+	if nano%1000000000 < 0 {
+		sec--
+	}
+	ms := float64(sec) * 1000
+
+	var fields [6]int // year, month, day, hour, minute, second
+	parts := tc.zoneFmt.Call("formatToParts", ms)
+	for i := 0; i < parts.Length(); i++ {
+		part := parts.Index(i)
+		idx := -1
+		switch part.Get("type").String() {
+		case "year":
+			idx = 0
+		case "month":
+			idx = 1
+		case "day":
+			idx = 2
+		case "hour":
+			idx = 3
+		case "minute":
+			idx = 4
+		case "second":
+			idx = 5
+		}
+		if idx >= 0 {
+			n, _ := Convert(part.Get("value").String()).Int()
+			fields[idx] = n
+		}
+	}
+	if fields[3] == 24 { // some engines report midnight as 24 even with h23
+		fields[3] = 0
+	}
+
+	wallMs := tc.dateCtor.Call("UTC", fields[0], fields[1]-1, fields[2], fields[3], fields[4], fields[5]).Float()
+	return int((wallMs - ms) / 1000)
+}
+
+// local shifts a UnixNano instant so its UTC fields read as wall-clock time in the provider's zone.
+func (tc *timeClient) local(nano int64) int64 {
+	return nano + int64(tc.Offset(nano))*1000000000
+}
+
+// localDate returns a JS Date whose UTC fields read as wall-clock time in the provider's zone.
+// Milliseconds are floored with integer math first: float64(nano)/1e6 would round
+// 23:59:59.999999999 up to the next second.
+func (tc *timeClient) localDate(nano int64) js.Value {
+	local := tc.local(nano)
+	ms := local / 1000000
+	if local%1000000 < 0 {
+		ms--
+	}
+	return tc.dateCtor.New(float64(ms))
+}
+
 func (tc *timeClient) UnixNano() int64 {
 	jsDate := tc.dateCtor.New()
 	msTimestamp := jsDate.Call("getTime").Float()
@@ -28,11 +160,22 @@ func (tc *timeClient) UnixNano() int64 {
 	return int64(msTimestamp) * 1000000
 }
 
+// MonotonicNano uses performance.now(), which has sub-millisecond resolution and never jumps with
+// the system clock. Falls back to Date.now() where the Performance API is missing.
+func (tc *timeClient) MonotonicNano() int64 {
+	if perf := js.Global().Get("performance"); perf.Truthy() {
+		return int64(perf.Call("now").Float() * 1000000)
+	}
+	return tc.UnixNano()
+}
+
 func (tc *timeClient) FormatDate(value any) string {
 	switch v := value.(type) {
 	case int64:
-		jsDate := tc.dateCtor.New(float64(v) / 1e6)
+		jsDate := tc.localDate(v)
 		return jsDate.Call("toISOString").String()[0:10]
+	case Date:
+		return v.String()
 	case string:
 		// Validate date format: YYYY-MM-DD (10 chars with dashes at positions 4 and 7)
 		if len(v) == 10 && v[4] == '-' && v[7] == '-' {
@@ -45,15 +188,17 @@ func (tc *timeClient) FormatDate(value any) string {
 func (tc *timeClient) FormatTime(value any) string {
 	switch v := value.(type) {
 	case int64: // UnixNano
-		jsDate := tc.dateCtor.New(float64(v) / 1e6)
+		jsDate := tc.localDate(v)
 		hours := jsDate.Call("getUTCHours").Int()
 		minutes := jsDate.Call("getUTCMinutes").Int()
 		seconds := jsDate.Call("getUTCSeconds").Int()
 		return Fmt("%02d:%02d:%02d", hours, minutes, seconds)
+	case TimeOfDay:
+		return v.String()
 	case int16: // Minutes since midnight
-		hours := v / 60
-		minutes := v % 60
-		return Fmt("%02d:%02d", hours, minutes)
+		return TimeOfDay(v).String()
+	case int32: // Seconds since midnight
+		return formatTimeSeconds(v)
 	case string:
 		if Count(v, ":") >= 1 {
 			return v
@@ -65,7 +210,7 @@ func (tc *timeClient) FormatTime(value any) string {
 func (tc *timeClient) FormatDateTime(value any) string {
 	switch v := value.(type) {
 	case int64:
-		jsDate := tc.dateCtor.New(float64(v) / 1e6)
+		jsDate := tc.localDate(v)
 		iso := jsDate.Call("toISOString").String()
 		return iso[0:10] + " " + iso[11:19]
 	case string:
@@ -80,7 +225,7 @@ func (tc *timeClient) FormatDateTime(value any) string {
 func (tc *timeClient) FormatDateTimeShort(value any) string {
 	switch v := value.(type) {
 	case int64:
-		jsDate := tc.dateCtor.New(float64(v) / 1e6)
+		jsDate := tc.localDate(v)
 		iso := jsDate.Call("toISOString").String()
 		return iso[0:10] + " " + iso[11:16]
 	case string:
@@ -92,51 +237,48 @@ func (tc *timeClient) FormatDateTimeShort(value any) string {
 	return ""
 }
 
-func (tc *timeClient) ParseDate(dateStr string) (int64, error) {
-	// Validate format: YYYY-MM-DD (10 chars with dashes at positions 4 and 7)
-	if len(dateStr) != 10 || dateStr[4] != '-' || dateStr[7] != '-' {
-		return 0, Errf("invalid date format: %s (expected YYYY-MM-DD)", dateStr)
-	}
+func (tc *timeClient) Format(value any, layout string) string {
+	return formatLayout(value, layout, tc.Offset)
+}
 
-	jsDate := tc.dateCtor.New(dateStr + "T00:00:00Z")
-	if jsDate.Call("toString").String() == "Invalid Date" {
-		return 0, Errf("invalid date format: %s", dateStr)
-	}
+func (tc *timeClient) FormatRFC3339(nano int64, offset int) string {
+	return formatRFC3339(nano, offset)
+}
 
-	// Verify date components match (JS Date auto-corrects invalid dates like Feb 30)
-	year := jsDate.Call("getUTCFullYear").Int()
-	month := jsDate.Call("getUTCMonth").Int() + 1
-	day := jsDate.Call("getUTCDate").Int()
-	expected := Fmt("%04d-%02d-%02d", year, month, day)
-	if expected != dateStr {
-		return 0, Errf("invalid date: %s (auto-corrected to %s)", dateStr, expected)
-	}
+func (tc *timeClient) ParseRFC3339(s string) (int64, error) {
+	return parseRFC3339(s)
+}
 
-	ms := jsDate.Call("getTime").Float()
-	return int64(ms) * 1000000, nil
+func (tc *timeClient) FormatRelative(nano int64) string {
+	return formatRelative(nano, tc.UnixNano())
 }
 
-func (tc *timeClient) ParseTime(timeStr string) (int16, error) {
+func (tc *timeClient) FormatRelativeTo(nano, base int64) string {
+	return formatRelative(nano, base)
+}
+
+func (tc *timeClient) ParseDate(dateStr string) (int64, error) {
+	return parseDateTime(dateStr, "", tc.Offset)
+}
+
+func (tc *timeClient) Parse(input string, layouts ...string) (int64, error) {
+	return parseLayouts(input, layouts, tc.Offset)
+}
+
+func (tc *timeClient) ParseTime(timeStr string) (TimeOfDay, error) {
 	return parseTime(timeStr)
 }
 
+func (tc *timeClient) ParseTimeSeconds(timeStr string) (int32, error) {
+	return parseTimeSeconds(timeStr)
+}
+
 func (tc *timeClient) ParseDateTime(dateStr, timeStr string) (int64, error) {
-	if len(timeStr) == 5 {
-		timeStr += ":00"
-	}
-	isoStr := dateStr + "T" + timeStr + "Z"
-	jsDate := tc.dateCtor.New(isoStr)
-	if jsDate.Call("toString").String() == "Invalid Date" {
-		return 0, Errf("invalid date/time format: %s %s", dateStr, timeStr)
-	}
-	ms := jsDate.Call("getTime").Float()
-	return int64(ms) * 1000000, nil
+	return parseDateTime(dateStr, timeStr, tc.Offset)
 }
 
 func (tc *timeClient) IsToday(nano int64) bool {
-	jsDate := tc.dateCtor.New(float64(nano) / 1e6)
-	now := tc.dateCtor.New()
-	return jsDate.Call("toDateString").String() == now.Call("toDateString").String()
+	return tc.FormatDate(nano) == tc.FormatDate(tc.UnixNano())
 }
 
 func (tc *timeClient) IsPast(nano int64) bool {
@@ -151,14 +293,108 @@ func (tc *timeClient) DaysBetween(nano1, nano2 int64) int {
 	return daysBetween(nano1, nano2)
 }
 
+func (tc *timeClient) CalendarDaysBetween(nano1, nano2 int64) int {
+	return calendarDaysBetween(nano1, nano2, tc.Offset)
+}
+
+func (tc *timeClient) DiffComponents(nano1, nano2 int64) DateDiff {
+	return diffComponents(nano1, nano2, tc.Offset)
+}
+
+func (tc *timeClient) AddDays(nano int64, n int) int64 {
+	return addDate(nano, 0, 0, n, tc.Offset)
+}
+
+func (tc *timeClient) AddMonths(nano int64, n int) int64 {
+	return addDate(nano, 0, n, 0, tc.Offset)
+}
+
+func (tc *timeClient) AddYears(nano int64, n int) int64 {
+	return addDate(nano, n, 0, 0, tc.Offset)
+}
+
+func (tc *timeClient) StartOfDay(nano int64) int64 {
+	return startOf(nano, periodDay, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) EndOfDay(nano int64) int64 {
+	return endOf(nano, periodDay, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) StartOfWeek(nano int64, firstDay Weekday) int64 {
+	return startOf(nano, periodWeek, firstDay, tc.Offset)
+}
+
+func (tc *timeClient) EndOfWeek(nano int64, firstDay Weekday) int64 {
+	return endOf(nano, periodWeek, firstDay, tc.Offset)
+}
+
+func (tc *timeClient) StartOfMonth(nano int64) int64 {
+	return startOf(nano, periodMonth, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) EndOfMonth(nano int64) int64 {
+	return endOf(nano, periodMonth, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) StartOfQuarter(nano int64) int64 {
+	return startOf(nano, periodQuarter, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) EndOfQuarter(nano int64) int64 {
+	return endOf(nano, periodQuarter, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) StartOfYear(nano int64) int64 {
+	return startOf(nano, periodYear, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) EndOfYear(nano int64) int64 {
+	return endOf(nano, periodYear, Sunday, tc.Offset)
+}
+
+func (tc *timeClient) Weekday(nano int64) Weekday {
+	return weekdayOf(nano, tc.Offset)
+}
+
+func (tc *timeClient) ISOWeek(nano int64) (year, week int) {
+	return isoWeek(nano, tc.Offset)
+}
+
+func (tc *timeClient) Quarter(nano int64) int {
+	return quarter(nano, tc.Offset)
+}
+
+func (tc *timeClient) DayOfYear(nano int64) int {
+	return dayOfYear(nano, tc.Offset)
+}
+
+func (tc *timeClient) FormatISOWeekDate(nano int64) string {
+	return formatISOWeekDate(nano, tc.Offset)
+}
+
+func (tc *timeClient) ParseISOWeekDate(s string) (int64, error) {
+	return parseISOWeekDate(s, tc.Offset)
+}
+
 // wasmTimer implements Timer for WASM using setTimeout
 type wasmTimer struct {
-	id     int
+	id     js.Value // setTimeout handle
 	active bool
 	jsFunc js.Func // Store to release later
 	f      func()  // Store callback to execute
 }
 
+// start allocates the JS callback and schedules it with setTimeout.
+func (wt *wasmTimer) start(milliseconds int) {
+	wt.jsFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
+		wt.fire()
+		return nil
+	})
+	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds)
+	wt.active = true
+}
+
 func (wt *wasmTimer) Stop() bool {
 	if !wt.active {
 		return false
@@ -169,6 +405,22 @@ func (wt *wasmTimer) Stop() bool {
 	return true
 }
 
+func (wt *wasmTimer) Reset(milliseconds int) bool {
+	if !wt.active {
+		// js.Func was released on Stop or fire; allocate a fresh one
+		wt.start(milliseconds)
+		return false
+	}
+	// Re-arm with the same js.Func, no new allocation
+	js.Global().Call("clearTimeout", wt.id)
+	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds)
+	return true
+}
+
+func (wt *wasmTimer) Active() bool {
+	return wt.active
+}
+
 func (wt *wasmTimer) fire() {
 	if !wt.active {
 		return
@@ -181,16 +433,61 @@ func (wt *wasmTimer) fire() {
 }
 
 func (tc *timeClient) AfterFunc(milliseconds int, f func()) Timer {
-	wt := &wasmTimer{
-		active: true,
-		f:      f,
-	}
+	wt := &wasmTimer{f: f}
+	wt.start(milliseconds)
+	return wt
+}
+
+// wasmTicker implements Ticker for WASM using setInterval
+type wasmTicker struct {
+	id     js.Value // setInterval handle
+	active bool
+	jsFunc js.Func // Store to release later
+	f      func()  // Store callback to execute
+}
 
+// start allocates the JS callback and schedules it with setInterval.
+func (wt *wasmTicker) start(milliseconds int) {
 	wt.jsFunc = js.FuncOf(func(this js.Value, args []js.Value) any {
-		wt.fire()
+		wt.tick()
 		return nil
 	})
+	wt.id = js.Global().Call("setInterval", wt.jsFunc, tickerInterval(milliseconds))
+	wt.active = true
+}
+
+func (wt *wasmTicker) Stop() bool {
+	if !wt.active {
+		return false
+	}
+	js.Global().Call("clearInterval", wt.id)
+	wt.active = false
+	wt.jsFunc.Release() // Free memory
+	return true
+}
+
+func (wt *wasmTicker) Reset(milliseconds int) bool {
+	if !wt.active {
+		wt.start(milliseconds)
+		return false
+	}
+	// Re-arm with the same js.Func, no new allocation
+	js.Global().Call("clearInterval", wt.id)
+	wt.id = js.Global().Call("setInterval", wt.jsFunc, tickerInterval(milliseconds))
+	return true
+}
+
+func (wt *wasmTicker) tick() {
+	if !wt.active {
+		return
+	}
+	if wt.f != nil {
+		wt.f()
+	}
+}
 
-	wt.id = js.Global().Call("setTimeout", wt.jsFunc, milliseconds).Int()
+func (tc *timeClient) Every(milliseconds int, f func()) Ticker {
+	wt := &wasmTicker{f: f}
+	wt.start(milliseconds)
 	return wt
 }
@@ -21,9 +21,18 @@ func TestAllSharedWasm(t *testing.T) {
 	t.Run("FormatDateTimeShort", func(t *testing.T) { FormatDateTimeShortShared(t, tp) })
 	t.Run("ParseDate", func(t *testing.T) { ParseDateShared(t, tp) })
 	t.Run("ParseTime", func(t *testing.T) { ParseTimeShared(t, tp) })
+	t.Run("ParseTimeSeconds", func(t *testing.T) { ParseTimeSecondsShared(t, tp) })
 	t.Run("ParseDateTime", func(t *testing.T) { ParseDateTimeShared(t, tp) })
 	t.Run("IsToday", func(t *testing.T) { IsTodayShared(t, tp) })
 	t.Run("IsPast", func(t *testing.T) { IsPastShared(t, tp) })
 	t.Run("IsFuture", func(t *testing.T) { IsFutureShared(t, tp) })
 	t.Run("DaysBetween", func(t *testing.T) { DaysBetweenShared(t, tp) })
+	t.Run("Format", func(t *testing.T) { FormatShared(t, tp) })
+	t.Run("Parse", func(t *testing.T) { ParseShared(t, tp) })
+	t.Run("RFC3339", func(t *testing.T) { RFC3339Shared(t, tp) })
+	t.Run("FormatRelative", func(t *testing.T) { FormatRelativeShared(t, tp) })
+	t.Run("AddDate", func(t *testing.T) { AddDateShared(t, tp) })
+	t.Run("PeriodBounds", func(t *testing.T) { PeriodBoundsShared(t, tp) })
+	t.Run("CalendarDiff", func(t *testing.T) { CalendarDiffShared(t, tp) })
+	t.Run("ISOWeek", func(t *testing.T) { ISOWeekShared(t, tp) })
 }
@@ -105,3 +105,149 @@ func TestAfterFunc_NilCallback(t *testing.T) {
 	timer.Stop()
 	t.Log("AfterFunc nil callback - passed")
 }
+
+func TestEvery_StopReturnsTrue(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	ticker := tp.Every(1000, func() {})
+	if ticker == nil {
+		t.Fatal("Every should return non-nil Ticker")
+	}
+
+	if !ticker.Stop() {
+		t.Error("Stop() should return true for active ticker")
+	}
+	if ticker.Stop() {
+		t.Error("Stop() should return false for already-stopped ticker")
+	}
+	t.Log("Every Stop - passed")
+}
+
+func TestEvery_CallbackLogic(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	count := 0
+
+	ticker := tp.Every(1000, func() {
+		count++
+	})
+
+	// Manually trigger ticks - the ticker stays active between them
+	tinytime.FireTicker(ticker)
+	tinytime.FireTicker(ticker)
+	if count != 2 {
+		t.Errorf("count = %d; want 2", count)
+	}
+
+	if !ticker.Reset(500) {
+		t.Error("Reset() should return true for active ticker")
+	}
+	tinytime.FireTicker(ticker)
+	if count != 3 {
+		t.Errorf("count after Reset = %d; want 3", count)
+	}
+
+	ticker.Stop()
+	tinytime.FireTicker(ticker) // no-op once stopped
+	if count != 3 {
+		t.Errorf("count after Stop = %d; want 3", count)
+	}
+
+	// Reset restarts a stopped ticker
+	if ticker.Reset(500) {
+		t.Error("Reset() should return false for stopped ticker")
+	}
+	tinytime.FireTicker(ticker)
+	if count != 4 {
+		t.Errorf("count after restart = %d; want 4", count)
+	}
+	ticker.Stop()
+	t.Log("Every callback logic - passed")
+}
+
+func TestAfterFunc_ResetActive(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	count := 0
+
+	timer := tp.AfterFunc(1000, func() {
+		count++
+	})
+
+	if !timer.Active() {
+		t.Error("Active() should return true for armed timer")
+	}
+
+	// Reset while active re-arms with the same callback
+	if !timer.Reset(500) {
+		t.Error("Reset() should return true for active timer")
+	}
+	tinytime.FireTimer(timer)
+	if count != 1 {
+		t.Errorf("count = %d; want 1", count)
+	}
+	if timer.Active() {
+		t.Error("Active() should return false after timer fired")
+	}
+
+	// Reset after firing re-arms the timer
+	if timer.Reset(500) {
+		t.Error("Reset() should return false for fired timer")
+	}
+	if !timer.Active() {
+		t.Error("Active() should return true after Reset")
+	}
+	tinytime.FireTimer(timer)
+	if count != 2 {
+		t.Errorf("count after re-arm = %d; want 2", count)
+	}
+
+	// Reset after Stop re-arms too
+	timer.Reset(500)
+	timer.Stop()
+	if timer.Active() {
+		t.Error("Active() should return false after Stop")
+	}
+	timer.Reset(500)
+	tinytime.FireTimer(timer)
+	if count != 3 {
+		t.Errorf("count after Stop+Reset = %d; want 3", count)
+	}
+	t.Log("AfterFunc Reset/Active - passed")
+}
+
+func TestDebounce_JSFuncLifecycle(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	count := 0
+	d := tinytime.Debounce(tp, 1000, func() { count++ })
+
+	// Calls within a burst re-arm the same setTimeout callback
+	d.Call()
+	first := tinytime.LimiterTimer(d)
+	d.Call()
+	if tinytime.LimiterTimer(d) != first {
+		t.Error("calls within a burst should reuse the timer")
+	}
+	tinytime.FireTimer(first)
+	if count != 1 {
+		t.Errorf("count = %d; want 1", count)
+	}
+
+	// The fired timer released its js.Func; the next burst allocates a new timer
+	d.Call()
+	second := tinytime.LimiterTimer(d)
+	if second == first || !second.Active() {
+		t.Error("a new burst should arm a new timer")
+	}
+	tinytime.FireTimer(first) // stale callback of the old timer is a no-op
+	if count != 1 {
+		t.Errorf("count after stale fire = %d; want 1", count)
+	}
+
+	d.Cancel()
+	if second.Active() {
+		t.Error("Cancel should stop the timer")
+	}
+	tinytime.FireTimer(second)
+	if count != 1 {
+		t.Errorf("count after Cancel = %d; want 1", count)
+	}
+}
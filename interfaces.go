@@ -1,17 +1,30 @@
 package tinytime
 
 // TimeProvider defines the interface for time utilities, implemented for both standard Go and WASM/JS environments.
+// Calendar operations run in the provider's time zone: UTC for NewTimeProvider, or the zone given to NewTimeProviderInZone.
 type TimeProvider interface {
 	// UnixNano retrieves the current Unix timestamp in nanoseconds.
 	// e.g., 1624397134562544800
 	UnixNano() int64
 
+	// MonotonicNano returns a monotonic clock reading in nanoseconds for measuring elapsed time.
+	// It is unaffected by wall-clock adjustments; only differences between readings are meaningful.
+	MonotonicNano() int64
+
+	// Zone returns the IANA name of the provider's time zone, e.g. "UTC" or "America/Santiago".
+	Zone() string
+
+	// Offset returns the provider's zone offset in seconds east of UTC at the given UnixNano instant.
+	// e.g., -10800 for "America/Santiago" in January.
+	Offset(nano int64) int
+
 	// FormatDate formats a value into a date string: "YYYY-MM-DD".
-	// Accepts: int64 (UnixNano), string ("2024-01-15").
+	// Accepts: int64 (UnixNano), Date, string ("2024-01-15").
 	FormatDate(value any) string
 
 	// FormatTime formats a value into a time string.
-	// Accepts: int64 (UnixNano) -> "HH:MM:SS", int16 (minutes) -> "HH:MM", string ("08:30").
+	// Accepts: int64 (UnixNano) -> "HH:MM:SS", TimeOfDay or int16 (minutes) -> "HH:MM",
+	// int32 (seconds) -> "HH:MM:SS", string ("08:30"). Out-of-range minutes or seconds return "".
 	FormatTime(value any) string
 
 	// FormatDateTime formats a value into a date-time string: "YYYY-MM-DD HH:MM:SS".
@@ -22,16 +35,47 @@ type TimeProvider interface {
 	// Accepts: int64 (UnixNano), string ("2024-01-15 08:30").
 	FormatDateTimeShort(value any) string
 
-	// ParseDate parses a date string ("YYYY-MM-DD") into a UnixNano timestamp (at midnight UTC).
+	// Format formats a value with a custom layout, identically on every build.
+	// Layouts use tokens ("DD/MM/YYYY HH:mm") or Go's reference time ("02/01/2006 15:04").
+	// Accepts: int64 (UnixNano), Date.
+	Format(value any, layout string) string
+
+	// FormatRFC3339 formats a UnixNano timestamp as RFC 3339 / ISO 8601 with nanosecond precision,
+	// shifted to a fixed offset in seconds east of UTC (e.g. tp.Offset(nano)).
+	// e.g., "2024-01-15T05:30:00.123456789-03:00", or "2024-01-15T08:30:00Z" for offset 0.
+	FormatRFC3339(nano int64, offset int) string
+
+	// ParseRFC3339 parses an RFC 3339 / ISO 8601 timestamp ("2024-01-15T08:30:00.123-03:00") into UnixNano,
+	// keeping up to nanosecond precision. The offset ("Z" or "±hh:mm") is required.
+	ParseRFC3339(s string) (int64, error)
+
+	// FormatRelative describes a UnixNano timestamp relative to now, e.g. "3 hours ago" or "in 2 days".
+	// Output follows the language set with tinystring.OutLang (e.g. "hace 3 horas" for ES).
+	FormatRelative(nano int64) string
+
+	// FormatRelativeTo describes a UnixNano timestamp relative to base instead of now.
+	FormatRelativeTo(nano, base int64) string
+
+	// ParseDate parses a date string ("YYYY-MM-DD") into a UnixNano timestamp (at midnight in the provider's zone).
 	ParseDate(dateStr string) (int64, error)
 
+	// Parse parses input with the first matching layout (same syntax as Format) into a UnixNano timestamp.
+	// Without layouts it tries "YYYY-MM-DD[T]HH:mm:ssZ", "YYYY-MM-DD HH:mm[:ss]", "YYYY-MM-DD",
+	// "D/M/YYYY [H:mm[:ss]]" and "D-M-YYYY [H:mm[:ss]]". Fields are interpreted in the provider's zone
+	// unless the input carries an offset. Invalid dates such as "2024-02-30" are rejected.
+	Parse(input string, layouts ...string) (int64, error)
+
 	// ParseTime parses a time string ("HH:MM" or "HH:MM:SS") into minutes since midnight.
-	ParseTime(timeStr string) (int16, error)
+	ParseTime(timeStr string) (TimeOfDay, error)
+
+	// ParseTimeSeconds parses "HH:MM", "HH:MM:SS" or "HH:MM:SS.fff" into seconds since midnight.
+	// Components must be two digits and in range; a fractional part is validated and truncated.
+	ParseTimeSeconds(timeStr string) (int32, error)
 
-	// ParseDateTime combines date and time strings into a single UnixNano timestamp (UTC).
+	// ParseDateTime combines date and time strings into a single UnixNano timestamp (in the provider's zone).
 	ParseDateTime(dateStr, timeStr string) (int64, error)
 
-	// IsToday checks if the given UnixNano timestamp is today (UTC).
+	// IsToday checks if the given UnixNano timestamp is today (in the provider's zone).
 	IsToday(nano int64) bool
 
 	// IsPast checks if the given UnixNano timestamp is in the past.
@@ -43,14 +87,106 @@ type TimeProvider interface {
 	// DaysBetween calculates the number of full days between two UnixNano timestamps.
 	DaysBetween(nano1, nano2 int64) int
 
+	// CalendarDaysBetween counts the date boundaries crossed from nano1 to nano2 in the provider's zone:
+	// 23:59 Monday to 00:01 Tuesday is 1. Negative when nano2 is on an earlier date.
+	CalendarDaysBetween(nano1, nano2 int64) int
+
+	// DiffComponents splits the span from nano1 to nano2 into years, months, days, hours, minutes and seconds
+	// in the provider's zone, e.g. for ages and tenure. All fields are negative when nano2 is earlier.
+	DiffComponents(nano1, nano2 int64) DateDiff
+
+	// AddDays adds n calendar days to a UnixNano timestamp in the provider's zone, keeping the wall-clock time
+	// across DST changes. n may be negative.
+	AddDays(nano int64, n int) int64
+
+	// AddMonths adds n calendar months, clamping to the last day of the target month:
+	// Jan 31 + 1 month = Feb 29 (leap year) or Feb 28.
+	AddMonths(nano int64, n int) int64
+
+	// AddYears adds n calendar years, clamping Feb 29 to Feb 28 in non-leap years.
+	AddYears(nano int64, n int) int64
+
+	// StartOfDay returns the UnixNano of midnight starting the day that contains nano, in the provider's zone.
+	StartOfDay(nano int64) int64
+
+	// EndOfDay returns the last nanosecond of the day that contains nano.
+	EndOfDay(nano int64) int64
+
+	// StartOfWeek returns the start of the week containing nano, with weeks beginning on firstDay
+	// (Monday for ISO 8601 weeks).
+	StartOfWeek(nano int64, firstDay Weekday) int64
+
+	// EndOfWeek returns the last nanosecond of the week containing nano, with weeks beginning on firstDay.
+	EndOfWeek(nano int64, firstDay Weekday) int64
+
+	// StartOfMonth returns the start of the first day of the month containing nano.
+	StartOfMonth(nano int64) int64
+
+	// EndOfMonth returns the last nanosecond of the month containing nano.
+	EndOfMonth(nano int64) int64
+
+	// StartOfQuarter returns the start of the quarter (Jan, Apr, Jul, Oct) containing nano.
+	StartOfQuarter(nano int64) int64
+
+	// EndOfQuarter returns the last nanosecond of the quarter containing nano.
+	EndOfQuarter(nano int64) int64
+
+	// StartOfYear returns the start of January 1st of the year containing nano.
+	StartOfYear(nano int64) int64
+
+	// EndOfYear returns the last nanosecond of the year containing nano.
+	EndOfYear(nano int64) int64
+
+	// Weekday returns the day of the week of nano in the provider's zone.
+	Weekday(nano int64) Weekday
+
+	// ISOWeek returns the ISO 8601 week-numbering year and week (1 to 53) of nano in the provider's zone.
+	// Weeks start on Monday and week 1 contains the year's first Thursday, so 2024-12-30 is in week 1 of 2025.
+	ISOWeek(nano int64) (year, week int)
+
+	// Quarter returns the quarter (1 to 4) of nano in the provider's zone.
+	Quarter(nano int64) int
+
+	// DayOfYear returns the day of the year (1 to 366) of nano in the provider's zone.
+	DayOfYear(nano int64) int
+
+	// FormatISOWeekDate formats nano as an ISO 8601 week date in the provider's zone: "2024-W03-1"
+	// (year, week, weekday from 1 = Monday to 7 = Sunday).
+	FormatISOWeekDate(nano int64) string
+
+	// ParseISOWeekDate parses an ISO 8601 week date ("2024-W03-1", "2024-W03" for its Monday, or the basic
+	// forms "2024W031" and "2024W03") into the UnixNano of midnight of that day in the provider's zone.
+	ParseISOWeekDate(s string) (int64, error)
+
 	// AfterFunc waits for the specified milliseconds then calls f.
 	// Returns a Timer that can be used to cancel the call.
 	// WARNING: In WASM, callback runs in JS event loop - keep it lightweight.
 	AfterFunc(milliseconds int, f func()) Timer
+
+	// Every calls f repeatedly every milliseconds (minimum 1) until the returned Ticker is stopped.
+	// WARNING: In WASM, callback runs in JS event loop - keep it lightweight.
+	Every(milliseconds int, f func()) Ticker
 }
 
 // Timer represents a cancelable timer
 type Timer interface {
 	// Stop prevents the timer from firing. Returns true if the timer was active.
 	Stop() bool
+
+	// Reset re-arms the timer to fire after milliseconds, whether it was active, stopped or already fired.
+	// Returns true if the timer was active. Useful for debouncing without allocating a new timer.
+	Reset(milliseconds int) bool
+
+	// Active reports whether the timer is armed and has not fired or been stopped.
+	Active() bool
+}
+
+// Ticker represents a cancelable repeating timer
+type Ticker interface {
+	// Stop stops the ticker. Returns true if the ticker was active.
+	Stop() bool
+
+	// Reset changes the ticker period to milliseconds, restarting it if it was stopped.
+	// Returns true if the ticker was active.
+	Reset(milliseconds int) bool
 }
@@ -0,0 +1,100 @@
+package tinytime
+
+// openEnd is the effective end of an open-ended Interval.
+const openEnd = 1<<63 - 1
+
+// Interval is the half-open span [Start, End) between two UnixNano timestamps:
+// Start is included and End is not, so back-to-back reservations do not overlap.
+// End == 0 means open-ended (no end yet, like a reservation with reserved_until = 0).
+// An interval with End <= Start (and End != 0) is empty.
+type Interval struct {
+	Start int64
+	End   int64
+}
+
+// IsOpen reports whether the interval has no end.
+func (iv Interval) IsOpen() bool {
+	return iv.End == 0
+}
+
+// IsEmpty reports whether the interval contains no instant.
+func (iv Interval) IsEmpty() bool {
+	return iv.End != 0 && iv.End <= iv.Start
+}
+
+// end returns End, or the largest timestamp for open-ended intervals.
+func (iv Interval) end() int64 {
+	if iv.End == 0 {
+		return openEnd
+	}
+	return iv.End
+}
+
+// intervalOf builds an Interval from an effective end, mapping openEnd back to End == 0.
+func intervalOf(start, end int64) Interval {
+	if end == openEnd {
+		end = 0
+	}
+	return Interval{Start: start, End: end}
+}
+
+// Contains reports whether nano falls inside the interval (Start included, End excluded).
+func (iv Interval) Contains(nano int64) bool {
+	return nano >= iv.Start && nano < iv.end()
+}
+
+// Overlaps reports whether both intervals share at least one instant.
+// Intervals that only touch (one ends where the other starts) do not overlap.
+func (iv Interval) Overlaps(other Interval) bool {
+	if iv.IsEmpty() || other.IsEmpty() {
+		return false
+	}
+	return iv.Start < other.end() && other.Start < iv.end()
+}
+
+// Intersect returns the span shared by both intervals, or false if they do not overlap.
+func (iv Interval) Intersect(other Interval) (Interval, bool) {
+	if !iv.Overlaps(other) {
+		return Interval{}, false
+	}
+	return intervalOf(max(iv.Start, other.Start), min(iv.end(), other.end())), true
+}
+
+// Union returns the span covering both intervals, or false if they neither overlap nor touch
+// (the result would include a gap that belongs to neither).
+func (iv Interval) Union(other Interval) (Interval, bool) {
+	if iv.IsEmpty() || other.IsEmpty() {
+		return Interval{}, false
+	}
+	if iv.Start > other.end() || other.Start > iv.end() {
+		return Interval{}, false
+	}
+	return intervalOf(min(iv.Start, other.Start), max(iv.end(), other.end())), true
+}
+
+// Duration returns the length of the interval in nanoseconds, -1 if it is open-ended and 0 if it is empty.
+func (iv Interval) Duration() int64 {
+	if iv.IsOpen() {
+		return -1
+	}
+	if iv.IsEmpty() {
+		return 0
+	}
+	return iv.End - iv.Start
+}
+
+// SplitByDay cuts the interval at each midnight in the provider's zone, returning one piece per
+// calendar day touched. Days shortened or lengthened by DST keep their real length.
+// Empty and open-ended intervals return nil.
+func (iv Interval) SplitByDay(tp TimeProvider) []Interval {
+	if iv.IsOpen() || iv.IsEmpty() {
+		return nil
+	}
+	var days []Interval
+	for start := iv.Start; start < iv.End; {
+		end := min(tp.EndOfDay(start)+1, iv.End)
+		days = append(days, Interval{Start: start, End: end})
+		start = end
+	}
+	return days
+}
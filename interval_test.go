@@ -0,0 +1,119 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+const hourNano = int64(3600000000000)
+
+func TestInterval_ContainsOverlaps(t *testing.T) {
+	base := int64(1705312800000000000) // 2024-01-15 10:00:00 UTC
+	morning := tinytime.Interval{Start: base, End: base + 2*hourNano}
+
+	if !morning.Contains(base) || !morning.Contains(base+2*hourNano-1) || morning.Contains(base+2*hourNano) {
+		t.Error("Contains should include Start and exclude End")
+	}
+
+	tests := []struct {
+		name  string
+		other tinytime.Interval
+		want  bool
+	}{
+		{"inside", tinytime.Interval{Start: base + hourNano/2, End: base + hourNano}, true},
+		{"partial", tinytime.Interval{Start: base + hourNano, End: base + 3*hourNano}, true},
+		{"touching after", tinytime.Interval{Start: base + 2*hourNano, End: base + 3*hourNano}, false},
+		{"touching before", tinytime.Interval{Start: base - hourNano, End: base}, false},
+		{"open-ended before", tinytime.Interval{Start: base - hourNano}, true},
+		{"open-ended after", tinytime.Interval{Start: base + 2*hourNano}, false},
+		{"empty", tinytime.Interval{Start: base + hourNano, End: base + hourNano}, false},
+	}
+	for _, tt := range tests {
+		if got := morning.Overlaps(tt.other); got != tt.want {
+			t.Errorf("Overlaps(%s) = %v; want %v", tt.name, got, tt.want)
+		}
+		if got := tt.other.Overlaps(morning); got != tt.want {
+			t.Errorf("Overlaps(%s) reversed = %v; want %v", tt.name, got, tt.want)
+		}
+	}
+
+	open := tinytime.Interval{Start: base}
+	if !open.IsOpen() || !open.Contains(base+1000*hourNano) || open.Contains(base-1) {
+		t.Error("open-ended interval should contain every instant from Start on")
+	}
+}
+
+func TestInterval_IntersectUnion(t *testing.T) {
+	a := tinytime.Interval{Start: 100, End: 300}
+	b := tinytime.Interval{Start: 200, End: 400}
+
+	if got, ok := a.Intersect(b); !ok || got != (tinytime.Interval{Start: 200, End: 300}) {
+		t.Errorf("Intersect = %v, %v; want {200 300}", got, ok)
+	}
+	if got, ok := a.Union(b); !ok || got != (tinytime.Interval{Start: 100, End: 400}) {
+		t.Errorf("Union = %v, %v; want {100 400}", got, ok)
+	}
+
+	// Touching intervals merge but do not intersect
+	c := tinytime.Interval{Start: 300, End: 350}
+	if _, ok := a.Intersect(c); ok {
+		t.Error("Intersect of touching intervals should fail")
+	}
+	if got, ok := a.Union(c); !ok || got != (tinytime.Interval{Start: 100, End: 350}) {
+		t.Errorf("Union(touching) = %v, %v; want {100 350}", got, ok)
+	}
+
+	// A gap cannot be covered by a union
+	if _, ok := a.Union(tinytime.Interval{Start: 301, End: 400}); ok {
+		t.Error("Union of disjoint intervals should fail")
+	}
+
+	// Open-ended intervals keep End == 0
+	open := tinytime.Interval{Start: 250}
+	if got, ok := a.Intersect(open); !ok || got != (tinytime.Interval{Start: 250, End: 300}) {
+		t.Errorf("Intersect(open) = %v, %v; want {250 300}", got, ok)
+	}
+	if got, ok := a.Union(open); !ok || got != (tinytime.Interval{Start: 100}) {
+		t.Errorf("Union(open) = %v, %v; want {100 0}", got, ok)
+	}
+	if got, ok := open.Intersect(tinytime.Interval{Start: 500}); !ok || got != (tinytime.Interval{Start: 500}) {
+		t.Errorf("Intersect(open, open) = %v, %v; want {500 0}", got, ok)
+	}
+}
+
+func TestInterval_Duration(t *testing.T) {
+	if got := (tinytime.Interval{Start: 100, End: 400}).Duration(); got != 300 {
+		t.Errorf("Duration = %d; want 300", got)
+	}
+	if got := (tinytime.Interval{Start: 100}).Duration(); got != -1 {
+		t.Errorf("Duration(open) = %d; want -1", got)
+	}
+	empty := tinytime.Interval{Start: 400, End: 100}
+	if !empty.IsEmpty() || empty.Duration() != 0 {
+		t.Errorf("reversed interval should be empty with Duration 0, got %d", empty.Duration())
+	}
+}
+
+func TestInterval_SplitByDay(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	start := int64(1705348800000000000) // 2024-01-15 20:00:00 UTC
+
+	days := tinytime.Interval{Start: start, End: start + 30*hourNano}.SplitByDay(tp)
+	want := []string{"2024-01-15 20:00 - 2024-01-16 00:00", "2024-01-16 00:00 - 2024-01-17 00:00", "2024-01-17 00:00 - 2024-01-17 02:00"}
+	if len(days) != len(want) {
+		t.Fatalf("SplitByDay returned %d pieces; want %d", len(days), len(want))
+	}
+	for i, d := range days {
+		if got := tp.FormatDateTimeShort(d.Start) + " - " + tp.FormatDateTimeShort(d.End); got != want[i] {
+			t.Errorf("piece %d = %s; want %s", i, got, want[i])
+		}
+	}
+
+	if got := (tinytime.Interval{Start: start, End: start + hourNano}).SplitByDay(tp); len(got) != 1 {
+		t.Errorf("same-day interval split into %d pieces; want 1", len(got))
+	}
+	if got := (tinytime.Interval{Start: start}).SplitByDay(tp); got != nil {
+		t.Errorf("SplitByDay(open) = %v; want nil", got)
+	}
+}
@@ -0,0 +1,645 @@
+package tinytime
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Layouts are written either with tokens ("DD/MM/YYYY HH:mm") or with Go's reference
+// time ("02/01/2006 15:04"). A layout containing any digit is read as a Go layout.
+// Both are compiled into the same chunks, so formatting and parsing are pure Go and
+// produce identical results on timeServer and timeClient.
+
+// layoutToken identifies a field placeholder inside a layout.
+type layoutToken uint8
+
+const (
+	tokLiteral       layoutToken = iota
+	tokYear                      // 2006, YYYY
+	tokYear2                     // 06, YY
+	tokMonthLong                 // January, MMMM
+	tokMonthShort                // Jan, MMM
+	tokMonth2                    // 01, MM
+	tokMonth                     // 1, M
+	tokDay2                      // 02, DD
+	tokDay                       // 2, D
+	tokDaySpace                  // _2
+	tokYearDay                   // 002
+	tokWeekdayLong               // Monday, dddd
+	tokWeekdayShort              // Mon, ddd
+	tokHour2                     // 15, HH
+	tokHour                      // H
+	tokHour12x2                  // 03, hh
+	tokHour12                    // 3, h
+	tokMinute2                   // 04, mm
+	tokMinute                    // 4, m
+	tokSecond2                   // 05, ss
+	tokSecond                    // 5, s
+	tokFraction                  // .000, .999, SSS
+	tokPM                        // PM, A
+	tokPMLower                   // pm, a
+	tokZone                      // Z07:00, Z: "Z" for UTC, otherwise ±hh:mm
+	tokZoneCompact               // Z0700: "Z" for UTC, otherwise ±hhmm
+	tokOffset                    // -07:00: always ±hh:mm
+	tokOffsetCompact             // -0700, ZZ: always ±hhmm
+)
+
+// layoutChunk is a compiled piece of a layout: a literal text or a field token.
+type layoutChunk struct {
+	tok    layoutToken
+	text   string // literal text, or the separator before a Go fraction ("." or ",")
+	digits int    // fraction digits
+	trim   bool   // fraction drops trailing zeros (Go ".999")
+}
+
+type layoutPattern struct {
+	text string
+	tok  layoutToken
+}
+
+// goLayoutPatterns lists Go reference-time placeholders, longest match first.
+var goLayoutPatterns = []layoutPattern{
+	{"January", tokMonthLong}, {"Jan", tokMonthShort},
+	{"Monday", tokWeekdayLong}, {"Mon", tokWeekdayShort},
+	{"2006", tokYear},
+	{"Z07:00", tokZone}, {"Z0700", tokZoneCompact},
+	{"-07:00", tokOffset}, {"-0700", tokOffsetCompact},
+	{"_2", tokDaySpace}, {"002", tokYearDay},
+	{"01", tokMonth2}, {"02", tokDay2}, {"03", tokHour12x2},
+	{"04", tokMinute2}, {"05", tokSecond2}, {"06", tokYear2},
+	{"15", tokHour2},
+	{"1", tokMonth}, {"2", tokDay}, {"3", tokHour12}, {"4", tokMinute}, {"5", tokSecond},
+	{"PM", tokPM}, {"pm", tokPMLower},
+}
+
+// tokenLayoutPatterns lists token placeholders, longest match first.
+var tokenLayoutPatterns = []layoutPattern{
+	{"YYYY", tokYear}, {"YY", tokYear2},
+	{"MMMM", tokMonthLong}, {"MMM", tokMonthShort}, {"MM", tokMonth2}, {"M", tokMonth},
+	{"DD", tokDay2}, {"D", tokDay},
+	{"dddd", tokWeekdayLong}, {"ddd", tokWeekdayShort},
+	{"HH", tokHour2}, {"H", tokHour}, {"hh", tokHour12x2}, {"h", tokHour12},
+	{"mm", tokMinute2}, {"m", tokMinute}, {"ss", tokSecond2}, {"s", tokSecond},
+	{"A", tokPM}, {"a", tokPMLower},
+	{"ZZ", tokOffsetCompact}, {"Z", tokZone},
+}
+
+var monthNames = [12]string{
+	"January", "February", "March", "April", "May", "June",
+	"July", "August", "September", "October", "November", "December",
+}
+
+var weekdayNames = [7]string{
+	"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday",
+}
+
+// compileLayout splits a layout into literal and token chunks.
+func compileLayout(layout string) []layoutChunk {
+	goMode := false
+	for i := 0; i < len(layout); i++ {
+		if layout[i] >= '0' && layout[i] <= '9' {
+			goMode = true
+			break
+		}
+	}
+
+	patterns := tokenLayoutPatterns
+	if goMode {
+		patterns = goLayoutPatterns
+	}
+
+	var chunks []layoutChunk
+	literal := func(text string) {
+		if n := len(chunks); n > 0 && chunks[n-1].tok == tokLiteral {
+			chunks[n-1].text += text
+			return
+		}
+		chunks = append(chunks, layoutChunk{tok: tokLiteral, text: text})
+	}
+
+	for i := 0; i < len(layout); {
+		// Go fraction: "." or "," followed by a run of 0s or 9s not followed by a digit
+		if goMode && (layout[i] == '.' || layout[i] == ',') && i+1 < len(layout) && (layout[i+1] == '0' || layout[i+1] == '9') {
+			j := i + 1
+			for j < len(layout) && layout[j] == layout[i+1] {
+				j++
+			}
+			if j == len(layout) || layout[j] < '0' || layout[j] > '9' {
+				chunks = append(chunks, layoutChunk{tok: tokFraction, text: layout[i : i+1], digits: min(j-i-1, 9), trim: layout[i+1] == '9'})
+				i = j
+				continue
+			}
+		}
+
+		if !goMode {
+			// [text] escapes literals in token layouts
+			if layout[i] == '[' {
+				end := i + 1
+				for end < len(layout) && layout[end] != ']' {
+					end++
+				}
+				literal(layout[i+1 : end])
+				i = end + 1
+				continue
+			}
+			// SSS... fraction digits
+			if layout[i] == 'S' {
+				j := i
+				for j < len(layout) && layout[j] == 'S' {
+					j++
+				}
+				chunks = append(chunks, layoutChunk{tok: tokFraction, digits: min(j-i, 9)})
+				i = j
+				continue
+			}
+		}
+
+		matched := false
+		for _, p := range patterns {
+			if len(layout)-i >= len(p.text) && layout[i:i+len(p.text)] == p.text {
+				chunks = append(chunks, layoutChunk{tok: p.tok})
+				i += len(p.text)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			literal(layout[i : i+1])
+			i++
+		}
+	}
+	return chunks
+}
+
+// formatLayout formats a value with a layout in the zone described by offset.
+// Accepts: int64 (UnixNano), Date (formatted at midnight, zone ignored).
+// Returns "" for unsupported values or an empty layout.
+func formatLayout(value any, layout string, offset func(nano int64) int) string {
+	if layout == "" {
+		return ""
+	}
+	switch v := value.(type) {
+	case int64:
+		return string(appendLayout(nil, wallClockOf(v, offset), compileLayout(layout)))
+	case Date:
+		return string(appendLayout(nil, wallClockOf(v.UnixNano(), utcOffset), compileLayout(layout)))
+	}
+	return ""
+}
+
+// appendLayout writes the wall-clock fields according to the compiled layout.
+func appendLayout(buf []byte, wc wallClock, chunks []layoutChunk) []byte {
+	for _, c := range chunks {
+		switch c.tok {
+		case tokLiteral:
+			buf = append(buf, c.text...)
+		case tokYear:
+			buf = appendInt(buf, wc.year, 4)
+		case tokYear2:
+			buf = appendInt(buf, (wc.year%100+100)%100, 2)
+		case tokMonthLong:
+			buf = append(buf, monthNames[wc.month-1]...)
+		case tokMonthShort:
+			buf = append(buf, monthNames[wc.month-1][:3]...)
+		case tokMonth2:
+			buf = appendInt(buf, wc.month, 2)
+		case tokMonth:
+			buf = appendInt(buf, wc.month, 0)
+		case tokDay2:
+			buf = appendInt(buf, wc.day, 2)
+		case tokDay:
+			buf = appendInt(buf, wc.day, 0)
+		case tokDaySpace:
+			if wc.day < 10 {
+				buf = append(buf, ' ')
+			}
+			buf = appendInt(buf, wc.day, 0)
+		case tokYearDay:
+			buf = appendInt(buf, wc.yearDay, 3)
+		case tokWeekdayLong:
+			buf = append(buf, weekdayNames[wc.weekday]...)
+		case tokWeekdayShort:
+			buf = append(buf, weekdayNames[wc.weekday][:3]...)
+		case tokHour2:
+			buf = appendInt(buf, wc.hour, 2)
+		case tokHour:
+			buf = appendInt(buf, wc.hour, 0)
+		case tokHour12x2:
+			buf = appendInt(buf, hour12(wc.hour), 2)
+		case tokHour12:
+			buf = appendInt(buf, hour12(wc.hour), 0)
+		case tokMinute2:
+			buf = appendInt(buf, wc.minute, 2)
+		case tokMinute:
+			buf = appendInt(buf, wc.minute, 0)
+		case tokSecond2:
+			buf = appendInt(buf, wc.second, 2)
+		case tokSecond:
+			buf = appendInt(buf, wc.second, 0)
+		case tokFraction:
+			buf = appendFraction(buf, wc.nanosecond, c)
+		case tokPM:
+			if wc.hour >= 12 {
+				buf = append(buf, "PM"...)
+			} else {
+				buf = append(buf, "AM"...)
+			}
+		case tokPMLower:
+			if wc.hour >= 12 {
+				buf = append(buf, "pm"...)
+			} else {
+				buf = append(buf, "am"...)
+			}
+		case tokZone, tokZoneCompact:
+			if wc.offset == 0 {
+				buf = append(buf, 'Z')
+				break
+			}
+			buf = appendOffset(buf, wc.offset, c.tok == tokZone)
+		case tokOffset:
+			buf = appendOffset(buf, wc.offset, true)
+		case tokOffsetCompact:
+			buf = appendOffset(buf, wc.offset, false)
+		}
+	}
+	return buf
+}
+
+// hour12 converts a 0-23 hour into 12-hour clock notation (1-12).
+func hour12(hour int) int {
+	if h := hour % 12; h != 0 {
+		return h
+	}
+	return 12
+}
+
+// appendInt writes n zero-padded to width digits (no padding when width is 0).
+func appendInt(buf []byte, n, width int) []byte {
+	if n < 0 {
+		buf = append(buf, '-')
+		n = -n
+	}
+	var digits [20]byte
+	i := len(digits)
+	for {
+		i--
+		digits[i] = byte('0' + n%10)
+		n /= 10
+		if n == 0 {
+			break
+		}
+	}
+	for pad := width - (len(digits) - i); pad > 0; pad-- {
+		buf = append(buf, '0')
+	}
+	return append(buf, digits[i:]...)
+}
+
+// appendFraction writes the nanosecond fraction with the chunk's digits, separator and trimming.
+func appendFraction(buf []byte, nanosecond int, c layoutChunk) []byte {
+	var digits [9]byte
+	for i := 8; i >= 0; i-- {
+		digits[i] = byte('0' + nanosecond%10)
+		nanosecond /= 10
+	}
+	frac := digits[:c.digits]
+	if c.trim {
+		for len(frac) > 0 && frac[len(frac)-1] == '0' {
+			frac = frac[:len(frac)-1]
+		}
+		if len(frac) == 0 {
+			return buf
+		}
+	}
+	buf = append(buf, c.text...)
+	return append(buf, frac...)
+}
+
+// appendOffset writes a zone offset in seconds as ±hh:mm (colon) or ±hhmm.
+func appendOffset(buf []byte, offset int, colon bool) []byte {
+	sign := byte('+')
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	buf = append(buf, sign)
+	buf = appendInt(buf, offset/3600, 2)
+	if colon {
+		buf = append(buf, ':')
+	}
+	return appendInt(buf, offset/60%60, 2)
+}
+
+// defaultParseLayouts are tried in order by Parse when no layouts are given.
+// Day-first numeric dates ("15/01/2024") follow the Latin American and European convention.
+var defaultParseLayouts = []string{
+	"YYYY-MM-DD[T]HH:mm:ssZ",
+	"YYYY-MM-DD[T]HH:mm:ss",
+	"YYYY-MM-DD[T]HH:mm",
+	"YYYY-MM-DD HH:mm:ss",
+	"YYYY-MM-DD HH:mm",
+	"YYYY-MM-DD",
+	"D/M/YYYY H:mm:ss",
+	"D/M/YYYY H:mm",
+	"D/M/YYYY",
+	"D-M-YYYY H:mm:ss",
+	"D-M-YYYY H:mm",
+	"D-M-YYYY",
+}
+
+// parseLayouts tries each layout in order (defaultParseLayouts when none are given)
+// and returns the UnixNano of the first match, interpreting wall-clock fields in the zone described by offset.
+func parseLayouts(input string, layouts []string, offset func(nano int64) int) (int64, error) {
+	if len(layouts) == 0 {
+		layouts = defaultParseLayouts
+	}
+	var firstErr error
+	for _, layout := range layouts {
+		nano, err := parseLayout(input, layout, offset)
+		if err == nil {
+			return nano, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	if len(layouts) == 1 {
+		return 0, firstErr
+	}
+	return 0, Errf("invalid date/time format: %s", input)
+}
+
+// parsedFields collects the values read from an input while walking a layout.
+type parsedFields struct {
+	year, month, day     int
+	yearDay              int
+	hour, minute, second int
+	nanosecond           int
+	pm, hasAMPM          bool
+	offset               int
+	hasOffset            bool
+}
+
+// parseLayout parses input with a single layout. Every field is range checked and the day
+// must exist in its month, so "2024-02-30" fails instead of rolling over to March.
+func parseLayout(input, layout string, offset func(nano int64) int) (int64, error) {
+	chunks := compileLayout(layout)
+	if len(chunks) == 0 {
+		return 0, Errf("empty layout")
+	}
+
+	pf := parsedFields{year: 1970, month: 1, day: 1}
+	fail := func() (int64, error) {
+		return 0, Errf("invalid date/time: %s (expected layout %s)", input, layout)
+	}
+
+	s := input
+	for ci, c := range chunks {
+		var ok bool
+		switch c.tok {
+		case tokLiteral:
+			if len(s) < len(c.text) || s[:len(c.text)] != c.text {
+				return fail()
+			}
+			s = s[len(c.text):]
+			ok = true
+		case tokYear:
+			pf.year, s, ok = parseDigits(s, 4, 4)
+		case tokYear2:
+			var yy int
+			if yy, s, ok = parseDigits(s, 2, 2); ok {
+				// Same pivot as Go: 69-99 -> 1900s, 00-68 -> 2000s
+				if yy >= 69 {
+					pf.year = 1900 + yy
+				} else {
+					pf.year = 2000 + yy
+				}
+			}
+		case tokMonthLong, tokMonthShort:
+			pf.month, s, ok = parseName(s, monthNames[:], c.tok == tokMonthShort)
+			pf.month++
+		case tokMonth2:
+			pf.month, s, ok = parseDigits(s, 2, 2)
+		case tokMonth:
+			pf.month, s, ok = parseDigits(s, 1, 2)
+		case tokDay2:
+			pf.day, s, ok = parseDigits(s, 2, 2)
+		case tokDay:
+			pf.day, s, ok = parseDigits(s, 1, 2)
+		case tokDaySpace:
+			if len(s) > 0 && s[0] == ' ' {
+				s = s[1:]
+			}
+			pf.day, s, ok = parseDigits(s, 1, 2)
+		case tokYearDay:
+			pf.yearDay, s, ok = parseDigits(s, 3, 3)
+		case tokWeekdayLong, tokWeekdayShort:
+			// Weekday names are validated but ignored, as in Go
+			_, s, ok = parseName(s, weekdayNames[:], c.tok == tokWeekdayShort)
+		case tokHour2:
+			pf.hour, s, ok = parseDigits(s, 2, 2)
+		case tokHour:
+			pf.hour, s, ok = parseDigits(s, 1, 2)
+		case tokHour12x2:
+			pf.hour, s, ok = parseDigits(s, 2, 2)
+			ok = ok && pf.hour >= 1 && pf.hour <= 12
+		case tokHour12:
+			pf.hour, s, ok = parseDigits(s, 1, 2)
+			ok = ok && pf.hour >= 1 && pf.hour <= 12
+		case tokMinute2:
+			pf.minute, s, ok = parseDigits(s, 2, 2)
+		case tokMinute:
+			pf.minute, s, ok = parseDigits(s, 1, 2)
+		case tokSecond2, tokSecond:
+			if c.tok == tokSecond2 {
+				pf.second, s, ok = parseDigits(s, 2, 2)
+			} else {
+				pf.second, s, ok = parseDigits(s, 1, 2)
+			}
+			// Accept a fractional second even when the layout has none, as Go does
+			if ok && len(s) > 1 && (s[0] == '.' || s[0] == ',') && s[1] >= '0' && s[1] <= '9' && !nextIsFraction(chunks, ci, s[0]) {
+				pf.nanosecond, s, ok = parseFraction(s[1:], 1, 9)
+			}
+		case tokFraction:
+			if c.text != "" { // Go layouts include the separator
+				if len(s) == 0 || (s[0] != '.' && s[0] != ',') {
+					ok = c.trim // ".999" is optional
+					break
+				}
+				s = s[1:]
+			}
+			if c.trim {
+				pf.nanosecond, s, ok = parseFraction(s, 1, 9)
+			} else {
+				pf.nanosecond, s, ok = parseFraction(s, c.digits, c.digits)
+			}
+		case tokPM, tokPMLower:
+			if len(s) >= 2 {
+				switch s[:2] {
+				case "AM", "am":
+					pf.hasAMPM, ok = true, true
+				case "PM", "pm":
+					pf.hasAMPM, pf.pm, ok = true, true, true
+				}
+				s = s[2:]
+			}
+		case tokZone, tokZoneCompact, tokOffset, tokOffsetCompact:
+			if len(s) > 0 && s[0] == 'Z' && (c.tok == tokZone || c.tok == tokZoneCompact) {
+				pf.hasOffset, pf.offset, ok = true, 0, true
+				s = s[1:]
+				break
+			}
+			pf.offset, s, ok = parseOffset(s, c.tok == tokZone || c.tok == tokOffset)
+			pf.hasOffset = ok
+		}
+		if !ok {
+			return fail()
+		}
+	}
+	if s != "" {
+		return fail()
+	}
+
+	if pf.hasAMPM {
+		if pf.hour < 1 || pf.hour > 12 {
+			return fail()
+		}
+		pf.hour %= 12
+		if pf.pm {
+			pf.hour += 12
+		}
+	}
+
+	if pf.yearDay > 0 {
+		if pf.yearDay > 365 && !(pf.yearDay == 366 && isLeapYear(pf.year)) {
+			return 0, Errf("invalid date: %s (day of year out of range)", input)
+		}
+		_, pf.month, pf.day = civilFromDays(daysFromCivil(pf.year, 1, 1) + int64(pf.yearDay-1))
+	}
+
+	if pf.month < 1 || pf.month > 12 {
+		return 0, Errf("invalid date: %s (month out of range)", input)
+	}
+	if pf.day < 1 || pf.day > daysInMonth(pf.year, pf.month) {
+		return 0, Errf("invalid date: %s (day out of range)", input)
+	}
+	if pf.hour > 23 || pf.minute > 59 || pf.second > 59 {
+		return 0, Errf("invalid time: %s (out of range)", input)
+	}
+
+	local := (daysFromCivil(pf.year, pf.month, pf.day)*86400+int64(pf.hour*3600+pf.minute*60+pf.second))*1000000000 + int64(pf.nanosecond)
+	if pf.hasOffset {
+		return local - int64(pf.offset)*1000000000, nil
+	}
+	return utcFromLocal(local, offset), nil
+}
+
+// nextIsFraction reports whether the chunk after ci reads the fraction itself,
+// either as a fraction token or as a literal separator followed by SSS.
+func nextIsFraction(chunks []layoutChunk, ci int, sep byte) bool {
+	if ci+1 == len(chunks) {
+		return false
+	}
+	next := chunks[ci+1]
+	return next.tok == tokFraction || (next.tok == tokLiteral && next.text[0] == sep)
+}
+
+// parseDigits reads between minDigits and maxDigits decimal digits from the start of s.
+func parseDigits(s string, minDigits, maxDigits int) (n int, rest string, ok bool) {
+	i := 0
+	for i < len(s) && i < maxDigits && s[i] >= '0' && s[i] <= '9' {
+		n = n*10 + int(s[i]-'0')
+		i++
+	}
+	if i < minDigits {
+		return 0, s, false
+	}
+	return n, s[i:], true
+}
+
+// parseFraction reads between minDigits and maxDigits fraction digits and returns them as nanoseconds.
+func parseFraction(s string, minDigits, maxDigits int) (nanosecond int, rest string, ok bool) {
+	start := len(s)
+	nanosecond, rest, ok = parseDigits(s, minDigits, maxDigits)
+	if !ok {
+		return 0, s, false
+	}
+	for i := start - len(rest); i < 9; i++ {
+		nanosecond *= 10
+	}
+	return nanosecond, rest, true
+}
+
+// parseName matches a month or weekday name (case-insensitive) at the start of s,
+// using the 3-letter abbreviation when short is true. Returns the name index.
+func parseName(s string, names []string, short bool) (index int, rest string, ok bool) {
+	for i, name := range names {
+		if short {
+			name = name[:3]
+		}
+		if len(s) >= len(name) && equalFold(s[:len(name)], name) {
+			return i, s[len(name):], true
+		}
+	}
+	return 0, s, false
+}
+
+// equalFold reports whether two ASCII strings are equal ignoring case.
+func equalFold(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		ca, cb := a[i], b[i]
+		if ca >= 'A' && ca <= 'Z' {
+			ca += 'a' - 'A'
+		}
+		if cb >= 'A' && cb <= 'Z' {
+			cb += 'a' - 'A'
+		}
+		if ca != cb {
+			return false
+		}
+	}
+	return true
+}
+
+// parseOffset reads a numeric zone offset, "±hh:mm" when colon is true or "±hhmm" otherwise,
+// and returns it in seconds east of UTC.
+func parseOffset(s string, colon bool) (offset int, rest string, ok bool) {
+	if len(s) == 0 || (s[0] != '+' && s[0] != '-') {
+		return 0, s, false
+	}
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	hours, rest, ok := parseDigits(s[1:], 2, 2)
+	if !ok {
+		return 0, s, false
+	}
+	if colon {
+		if len(rest) == 0 || rest[0] != ':' {
+			return 0, s, false
+		}
+		rest = rest[1:]
+	}
+	minutes, rest, ok := parseDigits(rest, 2, 2)
+	if !ok || hours > 23 || minutes > 59 {
+		return 0, s, false
+	}
+	return sign * (hours*3600 + minutes*60), rest, true
+}
+
+// rfc3339Layout is RFC 3339 with nanoseconds, dropping trailing fraction zeros.
+const rfc3339Layout = "2006-01-02T15:04:05.999999999Z07:00"
+
+// formatRFC3339 formats a UnixNano instant as RFC 3339 with a fixed offset in seconds east of UTC.
+func formatRFC3339(nano int64, offset int) string {
+	return formatLayout(nano, rfc3339Layout, func(int64) int { return offset })
+}
+
+// parseRFC3339 parses an RFC 3339 timestamp with optional fractional seconds (up to nanoseconds)
+// and a mandatory "Z" or "±hh:mm" offset.
+func parseRFC3339(s string) (int64, error) {
+	return parseLayout(s, rfc3339Layout, utcOffset)
+}
@@ -0,0 +1,198 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// Test Format
+func FormatShared(t *testing.T, tp tinytime.TimeProvider) {
+	nano := int64(1705307400123456789)      // 2024-01-15 08:30:00.123456789 UTC (Monday)
+	afternoon := int64(1705336245000000000) // 2024-01-15 16:30:45 UTC
+
+	tests := []struct {
+		value  any
+		layout string
+		want   string
+	}{
+		// Token layouts
+		{nano, "DD/MM/YYYY HH:mm", "15/01/2024 08:30"},
+		{nano, "D/M/YY H:m:s", "15/1/24 8:30:0"},
+		{nano, "YYYY-MM-DD[T]HH:mm:ss.SSS", "2024-01-15T08:30:00.123"},
+		{nano, "dddd, MMMM D", "Monday, January 15"},
+		{nano, "ddd MMM DD", "Mon Jan 15"},
+		{afternoon, "hh:mm A", "04:30 PM"},
+		{afternoon, "h:mm a", "4:30 pm"},
+		{nano, "HH:mm:ssZ", "08:30:00Z"},
+		{nano, "HH:mmZZ", "08:30+0000"},
+		{nano, "SSSSSSSSS", "123456789"},
+		// Go reference layouts
+		{nano, "02/01/2006 15:04", "15/01/2024 08:30"},
+		{nano, "2006-01-02T15:04:05.000Z07:00", "2024-01-15T08:30:00.123Z"},
+		{nano, "2006-01-02T15:04:05.999999999-07:00", "2024-01-15T08:30:00.123456789+00:00"},
+		{afternoon, "Mon Jan 2 3:04:05 PM 2006", "Mon Jan 15 4:30:45 PM 2024"},
+		{afternoon, "15:04:05.999", "16:30:45"},
+		{int64(0), "January 2, 2006", "January 1, 1970"},
+		// Before the epoch
+		{int64(-1000000000), "2006-01-02 15:04:05", "1969-12-31 23:59:59"},
+		// Unsupported
+		{123, "YYYY", ""},
+		{nano, "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tp.Format(tt.value, tt.layout); got != tt.want {
+			t.Errorf("Format(%v, %q) = %q; want %q", tt.value, tt.layout, got, tt.want)
+		}
+	}
+
+	t.Logf("Format tests passed")
+}
+
+// Test Parse
+func ParseShared(t *testing.T, tp tinytime.TimeProvider) {
+	const base = int64(1705307400000000000)     // 2024-01-15 08:30:00 UTC
+	const midnight = int64(1705276800000000000) // 2024-01-15 00:00:00 UTC
+
+	valid := []struct {
+		input   string
+		layouts []string
+		want    int64
+	}{
+		// Default layouts
+		{"2024-01-15T08:30:00Z", nil, base},
+		{"2024-01-15T08:30:00.123456789Z", nil, base + 123456789},
+		{"2024-01-15T05:30:00-03:00", nil, base},
+		{"2024-01-15 08:30:00", nil, base},
+		{"2024-01-15 08:30", nil, base},
+		{"2024-01-15", nil, midnight},
+		{"15/01/2024", nil, midnight},
+		{"15/1/2024 8:30", nil, base},
+		{"15-01-2024 8:30", nil, base},
+		// Explicit layouts
+		{"01/15/2024 08:30 AM", []string{"MM/DD/YYYY hh:mm A"}, base},
+		{"Jan 15, 2024 8:30pm", []string{"Jan 2, 2006 3:04pm"}, base + 12*3600*1000000000},
+		{"Monday, 15 january 2024", []string{"dddd, D MMMM YYYY"}, midnight},
+		{"2024 015", []string{"2006 002"}, midnight},
+		{"12:00 am 15/01/24", []string{"hh:mm a DD/MM/YY"}, midnight},
+		{"2024-01-15 08:30:00.250", []string{"YYYY-MM-DD HH:mm:ss.SSS"}, base + 250000000},
+		{"20240115T083000+0000", []string{"YYYYMMDD[T]HHmmssZZ"}, base},
+		// First matching layout wins
+		{"2024-01-15", []string{"DD/MM/YYYY", "YYYY-MM-DD"}, midnight},
+	}
+	for _, tt := range valid {
+		got, err := tp.Parse(tt.input, tt.layouts...)
+		if err != nil {
+			t.Errorf("Parse(%q, %v) failed: %v", tt.input, tt.layouts, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Parse(%q, %v) = %d; want %d", tt.input, tt.layouts, got, tt.want)
+		}
+	}
+
+	invalid := []struct {
+		input   string
+		layouts []string
+	}{
+		{"2024-02-30", nil},       // Feb 30 must not roll over to March
+		{"2023-02-29", nil},       // not a leap year
+		{"31/04/2024", nil},       // April has 30 days
+		{"2024-13-01", nil},       // month out of range
+		{"2024-01-15 24:00", nil}, // hour out of range
+		{"2024-01-15 08:60", nil}, // minute out of range
+		{"2024-01-15T08:30:00+25:00", nil},
+		{"2024-01-15 08:30 extra", nil}, // trailing text
+		{"invalid", nil},
+		{"", nil},
+		{"13:00 PM", []string{"hh:mm A"}},
+		{"2024-01-15", []string{"DD/MM/YYYY"}},
+	}
+	for _, tt := range invalid {
+		if _, err := tp.Parse(tt.input, tt.layouts...); err == nil {
+			t.Errorf("Parse(%q, %v) should return error", tt.input, tt.layouts)
+		}
+	}
+
+	// Round trip through Format
+	layout := "YYYY-MM-DD HH:mm:ss.SSSSSSSSS"
+	nano := int64(1705307400987654321)
+	got, err := tp.Parse(tp.Format(nano, layout), layout)
+	if err != nil || got != nano {
+		t.Errorf("Parse(Format(%d)) = %d, %v; want %d", nano, got, err, nano)
+	}
+
+	t.Logf("Parse tests passed")
+}
+
+// Test FormatRFC3339 and ParseRFC3339
+func RFC3339Shared(t *testing.T, tp tinytime.TimeProvider) {
+	nano := int64(1705307400123456789) // 2024-01-15 08:30:00.123456789 UTC
+
+	formats := []struct {
+		nano   int64
+		offset int
+		want   string
+	}{
+		{nano, 0, "2024-01-15T08:30:00.123456789Z"},
+		{nano, -3 * 3600, "2024-01-15T05:30:00.123456789-03:00"},
+		{nano, 5*3600 + 1800, "2024-01-15T14:00:00.123456789+05:30"},
+		{1705307400000000000, 0, "2024-01-15T08:30:00Z"},
+		{1705307400100000000, 0, "2024-01-15T08:30:00.1Z"},
+		{1705307400000000001, 0, "2024-01-15T08:30:00.000000001Z"},
+	}
+	for _, tt := range formats {
+		if got := tp.FormatRFC3339(tt.nano, tt.offset); got != tt.want {
+			t.Errorf("FormatRFC3339(%d, %d) = %q; want %q", tt.nano, tt.offset, got, tt.want)
+		}
+	}
+
+	parses := []struct {
+		input string
+		want  int64
+	}{
+		{"2024-01-15T08:30:00Z", 1705307400000000000},
+		{"2024-01-15T08:30:00.123456789Z", nano},
+		{"2024-01-15T05:30:00.123456789-03:00", nano},
+		{"2024-01-15T14:00:00.123456789+05:30", nano},
+		{"2024-01-15T08:30:00.1Z", 1705307400100000000},
+		{"2024-01-15T08:30:00,5Z", 1705307400500000000},
+		{"1969-12-31T23:59:59.999999999Z", -1},
+	}
+	for _, tt := range parses {
+		got, err := tp.ParseRFC3339(tt.input)
+		if err != nil {
+			t.Errorf("ParseRFC3339(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseRFC3339(%q) = %d; want %d", tt.input, got, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"2024-01-15T08:30:00",             // offset required
+		"2024-01-15 08:30:00Z",            // T separator required
+		"2024-01-15T08:30:00-0300",        // colon required in offset
+		"2024-01-15T08:30:00.1234567890Z", // more than nanoseconds
+		"2024-02-30T08:30:00Z",
+		"2024-01-15T08:30Z",
+		"",
+	}
+	for _, input := range invalid {
+		if _, err := tp.ParseRFC3339(input); err == nil {
+			t.Errorf("ParseRFC3339(%q) should return error", input)
+		}
+	}
+
+	// Round trip keeps nanosecond precision on every build
+	for _, offset := range []int{0, -4 * 3600, 9 * 3600} {
+		got, err := tp.ParseRFC3339(tp.FormatRFC3339(nano, offset))
+		if err != nil || got != nano {
+			t.Errorf("ParseRFC3339(FormatRFC3339(%d, %d)) = %d, %v; want %d", nano, offset, got, err, nano)
+		}
+	}
+
+	t.Logf("RFC3339 tests passed")
+}
@@ -0,0 +1,98 @@
+package tinytime
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// unitNames holds the singular and plural translations of a time unit.
+type unitNames struct {
+	one  LocStr
+	many LocStr
+}
+
+// Translations for relative times and durations. Languages without an entry fall back to English.
+var (
+	unitSecond = unitNames{
+		one:  LocStr{EN: "second", ES: "segundo", PT: "segundo", FR: "seconde", DE: "Sekunde"},
+		many: LocStr{EN: "seconds", ES: "segundos", PT: "segundos", FR: "secondes", DE: "Sekunden"},
+	}
+	unitMinute = unitNames{
+		one:  LocStr{EN: "minute", ES: "minuto", PT: "minuto", FR: "minute", DE: "Minute"},
+		many: LocStr{EN: "minutes", ES: "minutos", PT: "minutos", FR: "minutes", DE: "Minuten"},
+	}
+	unitHour = unitNames{
+		one:  LocStr{EN: "hour", ES: "hora", PT: "hora", FR: "heure", DE: "Stunde"},
+		many: LocStr{EN: "hours", ES: "horas", PT: "horas", FR: "heures", DE: "Stunden"},
+	}
+	unitDay = unitNames{
+		one:  LocStr{EN: "day", ES: "día", PT: "dia", FR: "jour", DE: "Tag"},
+		many: LocStr{EN: "days", ES: "días", PT: "dias", FR: "jours", DE: "Tagen"},
+	}
+	// durationDay names days in durations ("2 Tage 4 Stunden"): the German plural of unitDay is
+	// the dative that follows "vor" and "in".
+	durationDay = unitNames{
+		one:  unitDay.one,
+		many: LocStr{EN: "days", ES: "días", PT: "dias", FR: "jours", DE: "Tage"},
+	}
+	unitWeek = unitNames{
+		one:  LocStr{EN: "week", ES: "semana", PT: "semana", FR: "semaine", DE: "Woche"},
+		many: LocStr{EN: "weeks", ES: "semanas", PT: "semanas", FR: "semaines", DE: "Wochen"},
+	}
+	unitMonth = unitNames{
+		one:  LocStr{EN: "month", ES: "mes", PT: "mês", FR: "mois", DE: "Monat"},
+		many: LocStr{EN: "months", ES: "meses", PT: "meses", FR: "mois", DE: "Monaten"},
+	}
+	unitYear = unitNames{
+		one:  LocStr{EN: "year", ES: "año", PT: "ano", FR: "an", DE: "Jahr"},
+		many: LocStr{EN: "years", ES: "años", PT: "anos", FR: "ans", DE: "Jahren"},
+	}
+
+	relativePast   = LocStr{EN: "%d %s ago", ES: "hace %d %s", PT: "há %d %s", FR: "il y a %d %s", DE: "vor %d %s"}
+	relativeFuture = LocStr{EN: "in %d %s", ES: "en %d %s", PT: "em %d %s", FR: "dans %d %s", DE: "in %d %s"}
+	relativeNow    = LocStr{EN: "just now", ES: "ahora mismo", PT: "agora mesmo", FR: "à l'instant", DE: "gerade eben"}
+)
+
+// relativeThresholds picks the unit for a span: the first entry whose limit exceeds the span wins,
+// and the count is the span divided by the unit size.
+var relativeThresholds = []struct {
+	limit int64 // exclusive upper bound of the span in nanoseconds
+	size  int64 // unit size in nanoseconds
+	names unitNames
+}{
+	{60 * 1000000000, 1000000000, unitSecond},
+	{3600 * 1000000000, 60 * 1000000000, unitMinute},
+	{nanosPerDay, 3600 * 1000000000, unitHour},
+	{7 * nanosPerDay, nanosPerDay, unitDay},
+	{30 * nanosPerDay, 7 * nanosPerDay, unitWeek},
+	{365 * nanosPerDay, 30 * nanosPerDay, unitMonth},
+	{1<<63 - 1, 365 * nanosPerDay, unitYear},
+}
+
+// name returns the translated singular or plural unit name for count in the current language.
+func (u unitNames) name(count int64) string {
+	if count == 1 {
+		return Translate(u.one).String()
+	}
+	return Translate(u.many).String()
+}
+
+// formatRelative describes nano relative to base ("3 hours ago", "in 2 days") in the language set with tinystring.OutLang.
+// Counts are truncated, so 90 minutes reads as "1 hour ago". Spans under one second read as "just now".
+func formatRelative(nano, base int64) string {
+	span := nano - base
+	tpl := relativeFuture
+	if span < 0 {
+		span = -span
+		tpl = relativePast
+	}
+	if span < 1000000000 {
+		return Translate(relativeNow).String()
+	}
+	for _, th := range relativeThresholds {
+		if span < th.limit {
+			count := span / th.size
+			return Fmt(Translate(tpl).String(), count, th.names.name(count))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	. "github.com/cdvelop/tinystring"
+	"github.com/cdvelop/tinytime"
+)
+
+// Test FormatRelative and FormatRelativeTo
+func FormatRelativeShared(t *testing.T, tp tinytime.TimeProvider) {
+	defer OutLang(EN)
+
+	const base = int64(1705307400000000000) // 2024-01-15 08:30:00 UTC
+	const sec = int64(1000000000)
+	const day = 86400 * sec
+
+	tests := []struct {
+		offset int64
+		en, es string
+	}{
+		{0, "just now", "ahora mismo"},
+		{-sec / 2, "just now", "ahora mismo"},
+		{-sec, "1 second ago", "hace 1 segundo"},
+		{-45 * sec, "45 seconds ago", "hace 45 segundos"},
+		{5 * 60 * sec, "in 5 minutes", "en 5 minutos"},
+		{-90 * 60 * sec, "1 hour ago", "hace 1 hora"},
+		{-3 * 3600 * sec, "3 hours ago", "hace 3 horas"},
+		{2 * day, "in 2 days", "en 2 días"},
+		{-day, "1 day ago", "hace 1 día"},
+		{-14 * day, "2 weeks ago", "hace 2 semanas"},
+		{45 * day, "in 1 month", "en 1 mes"},
+		{-200 * day, "6 months ago", "hace 6 meses"},
+		{-400 * day, "1 year ago", "hace 1 año"},
+		{3 * 365 * day, "in 3 years", "en 3 años"},
+	}
+
+	OutLang(EN)
+	for _, tt := range tests {
+		if got := tp.FormatRelativeTo(base+tt.offset, base); got != tt.en {
+			t.Errorf("[EN] FormatRelativeTo(%+d) = %q; want %q", tt.offset, got, tt.en)
+		}
+	}
+
+	OutLang(ES)
+	for _, tt := range tests {
+		if got := tp.FormatRelativeTo(base+tt.offset, base); got != tt.es {
+			t.Errorf("[ES] FormatRelativeTo(%+d) = %q; want %q", tt.offset, got, tt.es)
+		}
+	}
+
+	// Relative to the provider's clock
+	OutLang(EN)
+	if got := tp.FormatRelative(tp.UnixNano() - 3*3600*sec); got != "3 hours ago" {
+		t.Errorf("FormatRelative(now-3h) = %q; want %q", got, "3 hours ago")
+	}
+
+	t.Logf("FormatRelative tests passed")
+}
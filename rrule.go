@@ -0,0 +1,532 @@
+package tinytime
+
+import (
+	"slices"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// Frequency is the base period of a recurrence rule (RFC 5545 FREQ).
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = [...]string{"", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// String returns the RFC 5545 name ("DAILY", "WEEKLY", ...).
+func (f Frequency) String() string {
+	if f < Daily || f > Yearly {
+		return ""
+	}
+	return frequencyNames[f]
+}
+
+// weekdayCodes are the RFC 5545 two-letter weekday codes, indexed by Weekday.
+var weekdayCodes = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// NthWeekday is a BYDAY entry: a weekday, optionally limited to its Nth occurrence in the month
+// (or year), counting from the end when N is negative. N == 0 matches every such weekday.
+type NthWeekday struct {
+	N   int
+	Day Weekday
+}
+
+// untilForm records how UNTIL was written so it can be compared and serialized the same way.
+type untilForm uint8
+
+const (
+	untilUTC      untilForm = iota // "20250630T235959Z": an instant
+	untilFloating                  // "20250630T235959": wall-clock time in the provider's zone
+	untilDate                      // "20250630": the whole day in the provider's zone
+)
+
+// RRule is a recurrence rule as defined by RFC 5545, limited to the DAILY, WEEKLY, MONTHLY and
+// YEARLY frequencies. Use ParseRRule to read one from its text form and String to write it back.
+type RRule struct {
+	Freq       Frequency
+	Interval   int          // periods between repetitions; 0 and 1 mean every period
+	Count      int          // maximum number of occurrences; 0 for no limit
+	Until      int64        // UnixNano of the last allowed occurrence (inclusive); 0 for no limit
+	ByDay      []NthWeekday // weekdays; N is only allowed with MONTHLY and YEARLY
+	ByMonthDay []int        // days of the month, 1 to 31 or -1 (last day) to -31
+	ByMonth    []int        // months, 1 to 12
+	BySetPos   []int        // positions within each period's occurrences, 1 to 366 or -1 (last) to -366
+	WeekStart  Weekday      // first day of the week for WEEKLY rules; ParseRRule defaults it to Monday
+
+	untilForm untilForm
+}
+
+// ParseRRule parses a recurrence rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20250630T235959Z",
+// with or without the "RRULE:" prefix. Rule parts that are not supported (BYHOUR, BYWEEKNO, ...) are
+// rejected instead of being ignored, so a rule never expands differently than written.
+// An UNTIL without "Z" is read as wall-clock time in the provider's zone at expansion.
+func ParseRRule(s string) (RRule, error) {
+	r := RRule{WeekStart: Monday}
+	s = Convert(s).TrimPrefix("RRULE:").String()
+	if s == "" {
+		return r, Errf("empty recurrence rule")
+	}
+
+	for _, part := range Convert(s).Split(";") {
+		eq := Index(part, "=")
+		if eq < 1 {
+			return r, Errf("invalid rule part: %s", part)
+		}
+		key, value := Convert(part[:eq]).ToUpper().String(), part[eq+1:]
+		var ok bool
+		switch key {
+		case "FREQ":
+			for f := Daily; f <= Yearly; f++ {
+				if equalFold(value, frequencyNames[f]) {
+					r.Freq, ok = f, true
+				}
+			}
+		case "INTERVAL":
+			r.Interval, ok = parseRuleInt(value, 1, 1<<30)
+		case "COUNT":
+			r.Count, ok = parseRuleInt(value, 1, 1<<30)
+		case "UNTIL":
+			ok = r.parseUntil(value)
+		case "BYDAY":
+			r.ByDay, ok = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, ok = parseRuleInts(value, 31)
+		case "BYMONTH":
+			r.ByMonth, ok = parseRuleInts(value, 12)
+			for _, m := range r.ByMonth {
+				ok = ok && m > 0
+			}
+		case "BYSETPOS":
+			r.BySetPos, ok = parseRuleInts(value, 366)
+		case "WKST":
+			var day int
+			if day, ok = parseWeekdayCode(value); ok {
+				r.WeekStart = Weekday(day)
+			}
+		default:
+			return r, Errf("unsupported rule part: %s", key)
+		}
+		if !ok {
+			return r, Errf("invalid %s: %s", key, value)
+		}
+	}
+
+	return r, r.validate()
+}
+
+// validate checks the combinations RFC 5545 forbids.
+func (r RRule) validate() error {
+	if r.Freq == 0 {
+		return Errf("recurrence rule without FREQ")
+	}
+	if r.Count > 0 && r.Until != 0 {
+		return Errf("COUNT and UNTIL cannot be combined")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return Errf("BYMONTHDAY is not allowed with FREQ=WEEKLY")
+	}
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, bd := range r.ByDay {
+			if bd.N != 0 {
+				return Errf("BYDAY with an ordinal requires FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	return nil
+}
+
+// parseUntil reads the three UNTIL forms: "YYYYMMDD", "YYYYMMDDTHHMMSS" and "YYYYMMDDTHHMMSSZ".
+func (r *RRule) parseUntil(value string) bool {
+	var err error
+	switch len(value) {
+	case 8:
+		r.Until, err = parseLayout(value, "YYYYMMDD", utcOffset)
+		r.Until += nanosPerDay - 1
+		r.untilForm = untilDate
+	case 15:
+		r.Until, err = parseLayout(value, "YYYYMMDD[T]HHmmss", utcOffset)
+		r.untilForm = untilFloating
+	case 16:
+		r.Until, err = parseLayout(value, "YYYYMMDD[T]HHmmss[Z]", utcOffset)
+		r.untilForm = untilUTC
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// parseRuleInt reads an optionally signed integer whose absolute value is between 1 and limit.
+func parseRuleInt(s string, minValue, limit int) (int, bool) {
+	sign := 1
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		if s[0] == '-' {
+			sign = -1
+		}
+		s = s[1:]
+	}
+	n, rest, ok := parseDigits(s, 1, 9)
+	if !ok || rest != "" || n < 1 || n > limit || sign*n < minValue {
+		return 0, false
+	}
+	return sign * n, true
+}
+
+// parseRuleInts reads a comma-separated list of non-zero integers within ±limit.
+func parseRuleInts(value string, limit int) ([]int, bool) {
+	var list []int
+	for _, item := range Convert(value).Split(",") {
+		n, ok := parseRuleInt(item, -limit, limit)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, n)
+	}
+	return list, true
+}
+
+// parseByDay reads a BYDAY list such as "MO,TH" or "-1FR,+2MO".
+func parseByDay(value string) ([]NthWeekday, bool) {
+	var list []NthWeekday
+	for _, item := range Convert(value).Split(",") {
+		if len(item) < 2 {
+			return nil, false
+		}
+		day, ok := parseWeekdayCode(item[len(item)-2:])
+		if !ok {
+			return nil, false
+		}
+		bd := NthWeekday{Day: Weekday(day)}
+		if prefix := item[:len(item)-2]; prefix != "" {
+			if bd.N, ok = parseRuleInt(prefix, -53, 53); !ok {
+				return nil, false
+			}
+		}
+		list = append(list, bd)
+	}
+	return list, true
+}
+
+// parseWeekdayCode returns the Weekday value of a two-letter code ("MO").
+func parseWeekdayCode(code string) (int, bool) {
+	for i, c := range weekdayCodes {
+		if equalFold(code, c) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// String returns the rule in RFC 5545 form without the "RRULE:" prefix, e.g. "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR".
+func (r RRule) String() string {
+	buf := append([]byte("FREQ="), r.Freq.String()...)
+	if r.Interval > 1 {
+		buf = appendInt(append(buf, ";INTERVAL="...), r.Interval, 0)
+	}
+	if r.Count > 0 {
+		buf = appendInt(append(buf, ";COUNT="...), r.Count, 0)
+	}
+	if r.Until != 0 {
+		buf = append(buf, ";UNTIL="...)
+		switch r.untilForm {
+		case untilDate:
+			buf = append(buf, formatLayout(r.Until, "YYYYMMDD", utcOffset)...)
+		case untilFloating:
+			buf = append(buf, formatLayout(r.Until, "YYYYMMDD[T]HHmmss", utcOffset)...)
+		default:
+			buf = append(buf, formatLayout(r.Until, "YYYYMMDD[T]HHmmss[Z]", utcOffset)...)
+		}
+	}
+	buf = appendRuleInts(buf, ";BYMONTH=", r.ByMonth)
+	buf = appendRuleInts(buf, ";BYMONTHDAY=", r.ByMonthDay)
+	for i, bd := range r.ByDay {
+		if i == 0 {
+			buf = append(buf, ";BYDAY="...)
+		} else {
+			buf = append(buf, ',')
+		}
+		if bd.N != 0 {
+			buf = appendInt(buf, bd.N, 0)
+		}
+		if bd.Day >= Sunday && bd.Day <= Saturday {
+			buf = append(buf, weekdayCodes[bd.Day]...)
+		}
+	}
+	buf = appendRuleInts(buf, ";BYSETPOS=", r.BySetPos)
+	if r.WeekStart != Monday && r.WeekStart >= Sunday && r.WeekStart <= Saturday {
+		buf = append(append(buf, ";WKST="...), weekdayCodes[r.WeekStart]...)
+	}
+	return string(buf)
+}
+
+// appendRuleInts writes key followed by the comma-separated list, or nothing for an empty list.
+func appendRuleInts(buf []byte, key string, list []int) []byte {
+	for i, n := range list {
+		if i == 0 {
+			buf = append(buf, key...)
+		} else {
+			buf = append(buf, ',')
+		}
+		buf = appendInt(buf, n, 0)
+	}
+	return buf
+}
+
+// Recurrence is a series of occurrences: the first one (DTSTART), the rule that repeats it and
+// the occurrences to skip (EXDATE). Every occurrence keeps the wall-clock time of Start in the
+// provider's zone, also across DST changes. Start is only an occurrence if it matches the rule.
+type Recurrence struct {
+	Start   int64   // UnixNano of the first occurrence
+	Rule    RRule   // repetition rule
+	ExDates []int64 // UnixNano of excluded occurrences; they still count toward Rule.Count
+}
+
+// gregorianCycleDays is the length of the 400-year Gregorian cycle, after which dates fall on the same
+// weekdays again. A rule without occurrences for a whole cycle (BYMONTHDAY=30;BYMONTH=2) never matches.
+const gregorianCycleDays = 146097
+
+// maxEmptyPeriods returns how many consecutive periods without occurrences cover a Gregorian cycle,
+// so rare but valid rules (a DAILY rule on Feb 29) keep expanding while impossible ones stop.
+func (r *RRule) maxEmptyPeriods() int {
+	switch r.Freq {
+	case Daily:
+		return gregorianCycleDays
+	case Weekly:
+		return gregorianCycleDays/7 + 1
+	case Monthly:
+		return 400 * 12
+	}
+	return 400
+}
+
+// RecurrenceIterator walks the occurrences of a Recurrence in chronological order.
+type RecurrenceIterator struct {
+	rc        Recurrence
+	offset    func(nano int64) int
+	startDays int64   // local day of Start
+	timeOfDay int64   // local wall-clock time of Start in nanoseconds
+	period    int     // index of the next period to expand
+	pending   []int64 // local days of the current period not yet returned
+	emitted   int
+	done      bool
+}
+
+// Iterator returns an iterator over the occurrences of rc in the provider's zone.
+func (rc Recurrence) Iterator(tp TimeProvider) *RecurrenceIterator {
+	it := &RecurrenceIterator{rc: rc, offset: tp.Offset}
+	it.startDays, it.timeOfDay = splitNano(rc.Start + int64(tp.Offset(rc.Start))*1000000000)
+	if rc.Rule.Freq < Daily || rc.Rule.Freq > Yearly {
+		it.done = true
+	}
+	return it
+}
+
+// Next returns the next occurrence, or false when the series has ended.
+// Series without COUNT or UNTIL never end; bound them with Between or by checking the result.
+func (it *RecurrenceIterator) Next() (int64, bool) {
+	rule := &it.rc.Rule
+	for !it.done {
+		if len(it.pending) == 0 {
+			it.done = !it.expand()
+			continue
+		}
+		local := it.pending[0]*nanosPerDay + it.timeOfDay
+		it.pending = it.pending[1:]
+		nano := utcFromLocal(local, it.offset)
+		if nano < it.rc.Start {
+			continue
+		}
+		if rule.Until != 0 && ((rule.untilForm == untilUTC && nano > rule.Until) || (rule.untilForm != untilUTC && local > rule.Until)) {
+			it.done = true
+			break
+		}
+		if rule.Count > 0 && it.emitted >= rule.Count {
+			it.done = true
+			break
+		}
+		it.emitted++
+		if slices.Contains(it.rc.ExDates, nano) {
+			continue
+		}
+		return nano, true
+	}
+	return 0, false
+}
+
+// expand fills pending with the next period that has candidates. Returns false if none is found.
+func (it *RecurrenceIterator) expand() bool {
+	interval := max(it.rc.Rule.Interval, 1)
+	limit := it.rc.Rule.maxEmptyPeriods()
+	for empty := 0; empty < limit; empty++ {
+		days := it.rc.Rule.periodDays(it.startDays, it.period*interval)
+		it.period++
+		if len(days) > 0 {
+			it.pending = days
+			return true
+		}
+	}
+	return false
+}
+
+// Between returns the occurrences in [from, to) in the provider's zone.
+func (rc Recurrence) Between(tp TimeProvider, from, to int64) []int64 {
+	var list []int64
+	it := rc.Iterator(tp)
+	for {
+		nano, ok := it.Next()
+		if !ok || nano >= to {
+			return list
+		}
+		if nano >= from {
+			list = append(list, nano)
+		}
+	}
+}
+
+// periodDays returns the sorted local days (since 1970-01-01) of the rule's candidates in the
+// period p periods after the one containing startDays, after BYSETPOS.
+func (r *RRule) periodDays(startDays int64, p int) []int64 {
+	year, month, day := civilFromDays(startDays)
+	var days []int64
+
+	switch r.Freq {
+	case Daily:
+		d := startDays + int64(p)
+		if r.inMonths(d) && r.matchesMonthDay(d) && r.matchesByDay(d, 0, 0) {
+			days = append(days, d)
+		}
+	case Weekly:
+		first := startDays - int64((weekdayFromDays(startDays)-r.WeekStart+7)%7) + 7*int64(p)
+		for d := first; d < first+7; d++ {
+			if len(r.ByDay) == 0 && weekdayFromDays(d) != weekdayFromDays(startDays) {
+				continue
+			}
+			if r.inMonths(d) && r.matchesByDay(d, 0, 0) {
+				days = append(days, d)
+			}
+		}
+	case Monthly:
+		total := year*12 + month - 1 + p
+		y, m := total/12, total%12+1
+		if len(r.ByMonth) == 0 || slices.Contains(r.ByMonth, m) {
+			days = r.monthDays(y, m, day)
+		}
+	case Yearly:
+		y := year + p
+		if len(r.ByDay) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 {
+			// BYDAY alone counts ordinals within the year: "20MO" is the 20th Monday
+			first := daysFromCivil(y, 1, 1)
+			length := daysFromCivil(y+1, 1, 1) - first
+			for d := first; d < first+length; d++ {
+				if r.matchesByDay(d, first, length) {
+					days = append(days, d)
+				}
+			}
+			break
+		}
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{month}
+			if len(r.ByMonthDay) > 0 || len(r.ByDay) > 0 {
+				months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+			}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(y, m, day)...)
+		}
+	}
+
+	slices.Sort(days)
+	days = slices.Compact(days)
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+	var picked []int64
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			picked = append(picked, days[i])
+		}
+	}
+	slices.Sort(picked)
+	return slices.Compact(picked)
+}
+
+// monthDays returns the candidate days of a month: the BYMONTHDAY days (limited by BYDAY),
+// the BYDAY days, or defaultDay when neither is set. Days that do not exist are skipped.
+func (r *RRule) monthDays(year, month, defaultDay int) []int64 {
+	first := daysFromCivil(year, month, 1)
+	length := int64(daysInMonth(year, month))
+	var days []int64
+	switch {
+	case len(r.ByMonthDay) > 0:
+		for d := first; d < first+length; d++ {
+			if r.matchesMonthDay(d) && r.matchesByDay(d, first, length) {
+				days = append(days, d)
+			}
+		}
+	case len(r.ByDay) > 0:
+		for d := first; d < first+length; d++ {
+			if r.matchesByDay(d, first, length) {
+				days = append(days, d)
+			}
+		}
+	case int64(defaultDay) <= length:
+		days = append(days, first+int64(defaultDay)-1)
+	}
+	return days
+}
+
+// inMonths reports whether day d falls in one of the BYMONTH months (always true without BYMONTH).
+func (r *RRule) inMonths(d int64) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	_, month, _ := civilFromDays(d)
+	return slices.Contains(r.ByMonth, month)
+}
+
+// matchesMonthDay reports whether day d is one of the BYMONTHDAY days (always true without BYMONTHDAY).
+func (r *RRule) matchesMonthDay(d int64) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	year, month, day := civilFromDays(d)
+	last := daysInMonth(year, month)
+	for _, md := range r.ByMonthDay {
+		if md == day || last+md+1 == day {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesByDay reports whether day d matches a BYDAY entry (always true without BYDAY).
+// Ordinals count within the span of length days starting at first.
+func (r *RRule) matchesByDay(d, first, length int64) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	wd := weekdayFromDays(d)
+	for _, bd := range r.ByDay {
+		if bd.Day != wd {
+			continue
+		}
+		switch {
+		case bd.N == 0:
+			return true
+		case bd.N > 0 && int64(bd.N) == (d-first)/7+1:
+			return true
+		case bd.N < 0 && int64(-bd.N) == (first+length-1-d)/7+1:
+			return true
+		}
+	}
+	return false
+}
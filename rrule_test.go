@@ -0,0 +1,213 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// expand returns the formatted occurrences of rule starting at start (a "YYYY-MM-DD HH:mm" string).
+func expand(t *testing.T, tp tinytime.TimeProvider, start, rule string, exdates ...string) []string {
+	t.Helper()
+	r, err := tinytime.ParseRRule(rule)
+	if err != nil {
+		t.Fatalf("ParseRRule(%s) failed: %v", rule, err)
+	}
+	rc := tinytime.Recurrence{Rule: r}
+	if rc.Start, err = tp.Parse(start); err != nil {
+		t.Fatalf("Parse(%s) failed: %v", start, err)
+	}
+	for _, ex := range exdates {
+		nano, _ := tp.Parse(ex)
+		rc.ExDates = append(rc.ExDates, nano)
+	}
+
+	var got []string
+	it := rc.Iterator(tp)
+	for len(got) < 50 {
+		nano, ok := it.Next()
+		if !ok {
+			break
+		}
+		got = append(got, tp.FormatDateTimeShort(nano))
+	}
+	return got
+}
+
+func checkOccurrences(t *testing.T, name string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("%s = %v; want %v", name, got, want)
+		return
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s = %v; want %v", name, got, want)
+			return
+		}
+	}
+}
+
+func TestRecurrence_Expand(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	tests := []struct {
+		name, start, rule string
+		want              []string
+	}{
+		{
+			"every 2 weeks on Mon and Thu", "2024-01-15 09:00", "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20240229T235959Z",
+			[]string{"2024-01-15 09:00", "2024-01-18 09:00", "2024-01-29 09:00", "2024-02-01 09:00", "2024-02-12 09:00", "2024-02-15 09:00", "2024-02-26 09:00", "2024-02-29 09:00"},
+		},
+		{
+			"weekly on start weekday", "2024-01-17 18:30", "FREQ=WEEKLY;COUNT=3",
+			[]string{"2024-01-17 18:30", "2024-01-24 18:30", "2024-01-31 18:30"},
+		},
+		{
+			"daily", "2024-02-27 08:00", "FREQ=DAILY;COUNT=4",
+			[]string{"2024-02-27 08:00", "2024-02-28 08:00", "2024-02-29 08:00", "2024-03-01 08:00"},
+		},
+		{
+			"every 3 days", "2024-01-30 20:00", "FREQ=DAILY;INTERVAL=3;COUNT=3",
+			[]string{"2024-01-30 20:00", "2024-02-02 20:00", "2024-02-05 20:00"},
+		},
+		{
+			"last Friday of the month", "2024-01-01 10:00", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			[]string{"2024-01-26 10:00", "2024-02-23 10:00", "2024-03-29 10:00"},
+		},
+		{
+			"second Tuesday", "2024-01-01 10:00", "FREQ=MONTHLY;BYDAY=+2TU;COUNT=2",
+			[]string{"2024-01-09 10:00", "2024-02-13 10:00"},
+		},
+		{
+			"31st skips short months", "2024-01-31 12:00", "FREQ=MONTHLY;COUNT=4",
+			[]string{"2024-01-31 12:00", "2024-03-31 12:00", "2024-05-31 12:00", "2024-07-31 12:00"},
+		},
+		{
+			"last day of the month", "2024-01-01 12:00", "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3",
+			[]string{"2024-01-31 12:00", "2024-02-29 12:00", "2024-03-31 12:00"},
+		},
+		{
+			"last working day", "2024-01-01 17:00", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=4",
+			[]string{"2024-01-31 17:00", "2024-02-29 17:00", "2024-03-29 17:00", "2024-04-30 17:00"},
+		},
+		{
+			"Friday the 13th", "2024-01-01 00:00", "FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13;COUNT=3",
+			[]string{"2024-09-13 00:00", "2024-12-13 00:00", "2025-06-13 00:00"},
+		},
+		{
+			"leap day", "2024-01-01 09:00", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29;COUNT=2",
+			[]string{"2024-02-29 09:00", "2028-02-29 09:00"},
+		},
+		{
+			"US Thanksgiving", "2024-01-01 12:00", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2",
+			[]string{"2024-11-28 12:00", "2025-11-27 12:00"},
+		},
+		{
+			"first Monday of the year", "2024-01-01 08:00", "FREQ=YEARLY;BYDAY=1MO;COUNT=2",
+			[]string{"2024-01-01 08:00", "2025-01-06 08:00"},
+		},
+		{
+			"quarterly on the 1st", "2024-01-01 08:00", "FREQ=YEARLY;BYMONTH=1,4,7,10;BYMONTHDAY=1;COUNT=5",
+			[]string{"2024-01-01 08:00", "2024-04-01 08:00", "2024-07-01 08:00", "2024-10-01 08:00", "2025-01-01 08:00"},
+		},
+		{
+			"date-only UNTIL is inclusive", "2024-01-01 23:00", "FREQ=DAILY;UNTIL=20240103",
+			[]string{"2024-01-01 23:00", "2024-01-02 23:00", "2024-01-03 23:00"},
+		},
+		{
+			"start not matching the rule", "2024-01-16 09:00", "FREQ=WEEKLY;BYDAY=MO;COUNT=2",
+			[]string{"2024-01-22 09:00", "2024-01-29 09:00"},
+		},
+		{
+			"leap day as a daily rule", "2024-02-29 09:00", "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=29;COUNT=3",
+			[]string{"2024-02-29 09:00", "2028-02-29 09:00", "2032-02-29 09:00"},
+		},
+		{
+			"leap day every 5 years", "2024-02-29 09:00", "FREQ=YEARLY;INTERVAL=5;COUNT=2",
+			[]string{"2024-02-29 09:00", "2044-02-29 09:00"},
+		},
+		{
+			"impossible rule ends", "2024-01-01 09:00", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			nil,
+		},
+		{
+			"impossible daily rule ends", "2024-01-01 09:00", "FREQ=DAILY;BYMONTH=2;BYMONTHDAY=30",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		checkOccurrences(t, tt.name, expand(t, tp, tt.start, tt.rule), tt.want)
+	}
+}
+
+func TestRecurrence_ExDates(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	// Excluded occurrences still count toward COUNT
+	got := expand(t, tp, "2024-01-15 08:00", "FREQ=DAILY;COUNT=4", "2024-01-16 08:00")
+	checkOccurrences(t, "exdate", got, []string{"2024-01-15 08:00", "2024-01-17 08:00", "2024-01-18 08:00"})
+}
+
+func TestRecurrence_Between(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	start, _ := tp.Parse("2024-01-01 08:00")
+	r, _ := tinytime.ParseRRule("RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	rc := tinytime.Recurrence{Start: start, Rule: r}
+
+	// Unbounded series expanded in a window
+	from, _ := tp.Parse("2024-03-04 00:00")
+	to, _ := tp.Parse("2024-03-11 08:00") // excludes Monday 11th at 08:00
+	var got []string
+	for _, nano := range rc.Between(tp, from, to) {
+		got = append(got, tp.FormatDateTimeShort(nano))
+	}
+	checkOccurrences(t, "Between", got, []string{"2024-03-04 08:00", "2024-03-06 08:00", "2024-03-08 08:00"})
+}
+
+func TestParseRRule(t *testing.T) {
+	// Round trip keeps the canonical order and UNTIL form
+	for _, rule := range []string{
+		"FREQ=WEEKLY;INTERVAL=2;UNTIL=20250630T235959Z;BYDAY=MO,TH",
+		"FREQ=MONTHLY;COUNT=3;BYDAY=-1FR",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29",
+		"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+		"FREQ=DAILY;UNTIL=20250630",
+		"FREQ=WEEKLY;UNTIL=20250630T090000;WKST=SU",
+	} {
+		r, err := tinytime.ParseRRule(rule)
+		if err != nil {
+			t.Errorf("ParseRRule(%s) failed: %v", rule, err)
+			continue
+		}
+		if got := r.String(); got != rule {
+			t.Errorf("String() = %s; want %s", got, rule)
+		}
+	}
+
+	r, err := tinytime.ParseRRule("rrule:freq=weekly;byday=mo,+2th;interval=2")
+	if err == nil {
+		t.Error("BYDAY ordinal with FREQ=WEEKLY should return error")
+	}
+	r, err = tinytime.ParseRRule("freq=monthly;byday=mo,+2th;interval=2")
+	if err != nil {
+		t.Fatalf("lowercase rule failed: %v", err)
+	}
+	if r.Freq != tinytime.Monthly || r.Interval != 2 || len(r.ByDay) != 2 || r.ByDay[1] != (tinytime.NthWeekday{N: 2, Day: tinytime.Thursday}) {
+		t.Errorf("ParseRRule(lowercase) = %+v", r)
+	}
+	if r.WeekStart != tinytime.Monday {
+		t.Errorf("default WeekStart = %s; want Monday", r.WeekStart)
+	}
+
+	for _, invalid := range []string{
+		"", "INTERVAL=2", "FREQ=HOURLY", "FREQ=DAILY;COUNT=2;UNTIL=20250101", "FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYMONTHDAY=0", "FREQ=YEARLY;BYMONTH=13", "FREQ=DAILY;BYHOUR=9", "FREQ=DAILY;INTERVAL=0",
+		"FREQ=WEEKLY;BYMONTHDAY=1", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;UNTIL=2025-06-30", "FREQ=DAILY;UNTIL=20250230",
+		"FREQ", "FREQ=DAILY;COUNT=-1",
+	} {
+		if _, err := tinytime.ParseRRule(invalid); err == nil {
+			t.Errorf("ParseRRule(%q) should return error", invalid)
+		}
+	}
+}
@@ -5,9 +5,10 @@ import (
 )
 
 // parseTime is a shared helper function for parsing time strings ("HH:MM" or "HH:MM:SS").
-func parseTime(timeStr string) (int16, error) {
+// Seconds are validated but not part of the result.
+func parseTime(timeStr string) (TimeOfDay, error) {
 	parts := Convert(timeStr).Split(":")
-	if len(parts) < 2 {
+	if len(parts) < 2 || len(parts) > 3 {
 		return 0, Errf("invalid time format: %s", timeStr)
 	}
 	hours, err := Convert(parts[0]).Int()
@@ -18,7 +19,72 @@ func parseTime(timeStr string) (int16, error) {
 	if err != nil || minutes < 0 || minutes > 59 {
 		return 0, Errf("invalid minutes: %s", parts[1])
 	}
-	return int16(hours*60 + minutes), nil
+	if len(parts) == 3 {
+		seconds, err := Convert(parts[2]).Int()
+		if err != nil || seconds < 0 || seconds > 59 {
+			return 0, Errf("invalid seconds: %s", parts[2])
+		}
+	}
+	return TimeOfDay(hours*60 + minutes), nil
+}
+
+// parseTimeSeconds parses "HH:MM", "HH:MM:SS" or "HH:MM:SS.fff" into seconds since midnight.
+// Every component must have exactly two digits and be in range; the fraction (1-9 digits,
+// after '.' or ',') is validated and truncated.
+func parseTimeSeconds(timeStr string) (int32, error) {
+	fail := func() (int32, error) {
+		return 0, Errf("invalid time: %s", timeStr)
+	}
+	hours, s, ok := parseDigits(timeStr, 2, 2)
+	if !ok || hours > 23 || len(s) == 0 || s[0] != ':' {
+		return fail()
+	}
+	minutes, s, ok := parseDigits(s[1:], 2, 2)
+	if !ok || minutes > 59 {
+		return fail()
+	}
+	var seconds int
+	if len(s) > 0 {
+		if s[0] != ':' {
+			return fail()
+		}
+		if seconds, s, ok = parseDigits(s[1:], 2, 2); !ok || seconds > 59 {
+			return fail()
+		}
+		if len(s) > 0 && (s[0] == '.' || s[0] == ',') {
+			if _, s, ok = parseFraction(s[1:], 1, 9); !ok {
+				return fail()
+			}
+		}
+		if len(s) > 0 {
+			return fail()
+		}
+	}
+	return int32(hours*3600 + minutes*60 + seconds), nil
+}
+
+// parseDateTime parses a "YYYY-MM-DD" date and an optional "HH:MM" or "HH:MM:SS" time in the zone
+// described by offset. Both builds share it, so invalid dates (Feb 30) and DST gaps are handled alike.
+func parseDateTime(dateStr, timeStr string, offset func(nano int64) int) (int64, error) {
+	switch {
+	case timeStr == "":
+		return parseLayout(dateStr, "YYYY-MM-DD", offset)
+	case len(timeStr) == 5:
+		return parseLayout(dateStr+" "+timeStr, "YYYY-MM-DD HH:mm", offset)
+	}
+	return parseLayout(dateStr+" "+timeStr, "YYYY-MM-DD HH:mm:ss", offset)
+}
+
+// formatTimeSeconds formats seconds since midnight as "HH:MM:SS", or "" if out of range.
+func formatTimeSeconds(seconds int32) string {
+	if seconds < 0 || seconds >= 86400 {
+		return ""
+	}
+	buf := appendInt(make([]byte, 0, 8), int(seconds/3600), 2)
+	buf = append(buf, ':')
+	buf = appendInt(buf, int(seconds/60%60), 2)
+	buf = append(buf, ':')
+	return string(appendInt(buf, int(seconds%60), 2))
 }
 
 // daysBetween is a shared helper function for calculating the number of full days between two timestamps.
@@ -27,3 +93,451 @@ func daysBetween(nano1, nano2 int64) int {
 	const nanosInDay = 86400000000000
 	return int((nano2 - nano1) / nanosInDay)
 }
+
+// utcFromLocal converts a wall-clock UnixNano value (local fields encoded as if they were UTC)
+// back to the real UTC instant, using the zone offset function in seconds.
+// A wall-clock time repeated by a DST overlap resolves to one of its instants; one skipped by a
+// DST gap resolves to the first instant after the gap (e.g. a skipped midnight becomes 01:00).
+func utcFromLocal(local int64, offset func(nano int64) int) int64 {
+	first := local - int64(offset(local))*1000000000
+	off := offset(first)
+	if first+int64(off)*1000000000 == local {
+		return first
+	}
+	second := local - int64(off)*1000000000
+	if second+int64(offset(second))*1000000000 == local {
+		return second
+	}
+	return max(first, second)
+}
+
+// utcOffset is the zone offset function for UTC and for values already in wall-clock time.
+func utcOffset(nano int64) int {
+	return 0
+}
+
+// tickerInterval clamps a repeating timer period to at least 1 millisecond.
+func tickerInterval(milliseconds int) int {
+	if milliseconds < 1 {
+		return 1
+	}
+	return milliseconds
+}
+
+// nanosPerDay is the number of nanoseconds in a civil day.
+const nanosPerDay = 86400000000000
+
+// splitNano splits a UnixNano value into days since 1970-01-01 and nanoseconds within that day.
+// Uses floor division so instants before the epoch land on the correct day.
+func splitNano(nano int64) (days, nanoOfDay int64) {
+	days = nano / nanosPerDay
+	nanoOfDay = nano % nanosPerDay
+	if nanoOfDay < 0 {
+		days--
+		nanoOfDay += nanosPerDay
+	}
+	return days, nanoOfDay
+}
+
+// civilFromDays converts days since 1970-01-01 into a proleptic Gregorian year, month (1-12) and day (1-31).
+// Based on Howard Hinnant's days_from_civil inverse algorithm.
+func civilFromDays(days int64) (year, month, day int) {
+	z := days + 719468
+	era := z / 146097
+	if z < 0 && z%146097 != 0 {
+		era--
+	}
+	doe := z - era*146097                                  // [0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // [0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // [0, 365]
+	mp := (5*doy + 2) / 153                                // [0, 11], March-based
+	day = int(doy - (153*mp+2)/5 + 1)
+	if mp < 10 {
+		month = int(mp + 3)
+	} else {
+		month = int(mp - 9)
+	}
+	year = int(yoe + era*400)
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+// daysFromCivil converts a proleptic Gregorian year, month (1-12) and day into days since 1970-01-01.
+func daysFromCivil(year, month, day int) int64 {
+	y := int64(year)
+	if month <= 2 {
+		y--
+	}
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+	yoe := y - era*400
+	m := int64(month)
+	if m > 2 {
+		m -= 3
+	} else {
+		m += 9
+	}
+	doy := (153*m+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// isLeapYear reports whether year is a leap year in the proleptic Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth returns the number of days in the given month (1-12) of year.
+func daysInMonth(year, month int) int {
+	switch month {
+	case 2:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// wallClock holds the calendar fields of an instant as seen in a time zone.
+type wallClock struct {
+	year, month, day     int
+	hour, minute, second int
+	nanosecond           int
+	weekday              int // 0 = Sunday
+	yearDay              int // 1-366
+	offset               int // seconds east of UTC
+}
+
+// wallClockOf breaks a UnixNano instant into calendar fields using the zone offset function.
+func wallClockOf(nano int64, offset func(nano int64) int) wallClock {
+	off := offset(nano)
+	days, nanoOfDay := splitNano(nano + int64(off)*1000000000)
+	wc := wallClock{offset: off}
+	wc.year, wc.month, wc.day = civilFromDays(days)
+	secs := int(nanoOfDay / 1000000000)
+	wc.hour = secs / 3600
+	wc.minute = secs / 60 % 60
+	wc.second = secs % 60
+	wc.nanosecond = int(nanoOfDay % 1000000000)
+	wc.weekday = int(weekdayFromDays(days))
+	wc.yearDay = int(days-daysFromCivil(wc.year, 1, 1)) + 1
+	return wc
+}
+
+// Duration is an elapsed time in nanoseconds with the same values as Go's time.Duration,
+// parsed and formatted without the time package to keep WASM binaries small.
+type Duration int64
+
+const (
+	Nanosecond  Duration = 1
+	Microsecond          = 1000 * Nanosecond
+	Millisecond          = 1000 * Microsecond
+	Second               = 1000 * Millisecond
+	Minute               = 60 * Second
+	Hour                 = 60 * Minute
+)
+
+// DurationStyle selects the output of FormatDuration.
+type DurationStyle uint8
+
+const (
+	DurationCompact DurationStyle = iota // "1h 30m", "2d 4h", "250ms"
+	DurationClock                        // "01:30:00"; hours are not wrapped into days ("36:00:00")
+	DurationLong                         // "1 hour 30 minutes" in the language set with tinystring.OutLang
+)
+
+// goDurationUnits are the unit suffixes of Go duration syntax; "ms" comes before "m" so it matches first.
+var goDurationUnits = []struct {
+	suffix string
+	unit   Duration
+}{
+	{"ns", Nanosecond}, {"us", Microsecond}, {"µs", Microsecond}, {"μs", Microsecond},
+	{"ms", Millisecond}, {"s", Second}, {"m", Minute}, {"h", Hour},
+}
+
+// durationAbbreviations are the short unit words accepted by ParseDuration besides the translated names.
+var durationAbbreviations = []struct {
+	word string
+	unit Duration
+}{
+	{"sec", Second}, {"secs", Second}, {"min", Minute}, {"mins", Minute}, {"hr", Hour}, {"hrs", Hour},
+}
+
+// ParseDuration parses a duration in one of three forms:
+//   - Go syntax: "1h30m", "-1.5h", "300ms", "2h45m30.5s" (units ns, us, µs, ms, s, m, h)
+//   - ISO 8601: "PT1H30M", "P1DT2H", "P2W", "PT0.5S" (days are 24 hours; years and months are rejected
+//     because their length depends on the date)
+//   - Words: "90 minutes", "1 hour 30 minutes", "2 días" (seconds to weeks in any supported language,
+//     plus sec, min and hr)
+func ParseDuration(s string) (Duration, error) {
+	neg := false
+	body := s
+	if len(body) > 0 && (body[0] == '-' || body[0] == '+') {
+		neg = body[0] == '-'
+		body = body[1:]
+	}
+
+	var d Duration
+	var ok bool
+	switch {
+	case len(body) > 0 && (body[0] == 'P' || body[0] == 'p'):
+		d, ok = parseISODuration(body[1:])
+	case body == "0":
+		d, ok = 0, true
+	default:
+		if d, ok = parseGoDuration(body); !ok {
+			d, ok = parseWordDuration(body)
+		}
+	}
+	if !ok {
+		return 0, Errf("invalid duration: %s", s)
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// parseDurationNumber reads digits with an optional fraction ("1.5" or "1,5" when comma is true).
+func parseDurationNumber(s string, comma bool) (whole, frac, scale int64, rest string, ok bool) {
+	i := 0
+	for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+		if whole > (1<<63-1)/10 {
+			return 0, 0, 0, s, false
+		}
+		whole = whole*10 + int64(s[i]-'0')
+	}
+	digits := i
+	scale = 1
+	if i < len(s) && (s[i] == '.' || (comma && s[i] == ',')) {
+		i++
+		for ; i < len(s) && s[i] >= '0' && s[i] <= '9'; i++ {
+			if scale < 1e18 { // digits beyond nanosecond precision are dropped
+				frac = frac*10 + int64(s[i]-'0')
+				scale *= 10
+			}
+			digits++
+		}
+	}
+	return whole, frac, scale, s[i:], digits > 0
+}
+
+// durationOf returns (whole + frac/scale) units, or false on overflow.
+func durationOf(whole, frac, scale int64, unit Duration) (Duration, bool) {
+	if whole > int64((1<<63-1)/unit) {
+		return 0, false
+	}
+	d := Duration(whole)*unit + Duration(float64(frac)*float64(unit)/float64(scale))
+	return d, d >= 0
+}
+
+// parseGoDuration parses the unsigned Go syntax ("1h30m").
+func parseGoDuration(s string) (Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	var total Duration
+	for s != "" {
+		whole, frac, scale, rest, ok := parseDurationNumber(s, false)
+		if !ok {
+			return 0, false
+		}
+		matched := false
+		for _, u := range goDurationUnits {
+			if len(rest) >= len(u.suffix) && rest[:len(u.suffix)] == u.suffix {
+				d, ok := durationOf(whole, frac, scale, u.unit)
+				if !ok || total > 1<<63-1-d {
+					return 0, false
+				}
+				total += d
+				s = rest[len(u.suffix):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return 0, false
+		}
+	}
+	return total, true
+}
+
+// parseISODuration parses an ISO 8601 duration after its leading "P".
+func parseISODuration(s string) (Duration, bool) {
+	var total Duration
+	inTime := false
+	parts := 0
+	for s != "" {
+		if s[0] == 'T' || s[0] == 't' {
+			if inTime || len(s) == 1 {
+				return 0, false
+			}
+			inTime = true
+			s = s[1:]
+			continue
+		}
+		whole, frac, scale, rest, ok := parseDurationNumber(s, true)
+		if !ok || rest == "" {
+			return 0, false
+		}
+		var unit Duration
+		switch rest[0] {
+		case 'W', 'w':
+			unit = 7 * 24 * Hour
+		case 'D', 'd':
+			unit = 24 * Hour
+		case 'H', 'h':
+			unit = Hour
+		case 'M', 'm':
+			unit = Minute
+		case 'S', 's':
+			unit = Second
+		default:
+			return 0, false
+		}
+		if inTime != (unit <= Hour) { // W and D belong before T; H, M and S after it (Y and M before T are rejected)
+			return 0, false
+		}
+		d, ok := durationOf(whole, frac, scale, unit)
+		if !ok || total > 1<<63-1-d {
+			return 0, false
+		}
+		total += d
+		s = rest[1:]
+		parts++
+	}
+	return total, parts > 0
+}
+
+// parseWordDuration parses number and unit word pairs ("1 hour 30 minutes").
+func parseWordDuration(s string) (Duration, bool) {
+	words := Convert(s).Split()
+	if len(words) == 0 || len(words)%2 != 0 {
+		return 0, false
+	}
+	var total Duration
+	for i := 0; i < len(words); i += 2 {
+		whole, frac, scale, rest, ok := parseDurationNumber(words[i], true)
+		if !ok || rest != "" {
+			return 0, false
+		}
+		unit, ok := durationWordUnit(Convert(words[i+1]).TrimSuffix(",").String())
+		if !ok {
+			return 0, false
+		}
+		d, ok := durationOf(whole, frac, scale, unit)
+		if !ok || total > 1<<63-1-d {
+			return 0, false
+		}
+		total += d
+	}
+	return total, true
+}
+
+// durationWordUnit matches a unit name in any supported language or a short English word.
+func durationWordUnit(word string) (Duration, bool) {
+	for _, u := range durationAbbreviations {
+		if equalFold(word, u.word) {
+			return u.unit, true
+		}
+	}
+	for _, u := range durationUnits {
+		for _, names := range [2]LocStr{u.names.one, u.names.many} {
+			for _, name := range names {
+				if name != "" && equalFold(word, name) {
+					return u.unit, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// durationUnits are the units of the long format, largest first; weeks are only parsed.
+var durationUnits = []struct {
+	unit    Duration
+	names   unitNames
+	compact string
+	format  bool
+}{
+	{7 * 24 * Hour, unitWeek, "w", false},
+	{24 * Hour, durationDay, "d", true},
+	{Hour, unitHour, "h", true},
+	{Minute, unitMinute, "m", true},
+	{Second, unitSecond, "s", true},
+}
+
+// FormatDuration formats d in the given style. See DurationCompact, DurationClock and DurationLong.
+func FormatDuration(d Duration, style DurationStyle) string {
+	var buf []byte
+	if d < 0 {
+		buf = append(buf, '-')
+		d = -d // the minimum Duration stays negative and formats as its parts below
+	}
+	switch style {
+	case DurationClock:
+		total := uint64(d) / uint64(Second)
+		buf = appendInt(buf, int(total/3600), 2)
+		buf = append(buf, ':')
+		buf = appendInt(buf, int(total/60%60), 2)
+		buf = append(buf, ':')
+		return string(appendInt(buf, int(total%60), 2))
+	case DurationLong:
+		rest := uint64(d)
+		start := len(buf)
+		for _, u := range durationUnits {
+			if !u.format || rest < uint64(u.unit) {
+				continue
+			}
+			count := int64(rest / uint64(u.unit))
+			rest %= uint64(u.unit)
+			if len(buf) > start {
+				buf = append(buf, ' ')
+			}
+			buf = appendInt(buf, int(count), 0)
+			buf = append(append(buf, ' '), u.names.name(count)...)
+		}
+		if len(buf) == start {
+			buf = append(append(buf, "0 "...), unitSecond.name(0)...)
+		}
+		return string(buf)
+	}
+
+	rest := uint64(d)
+	if rest < uint64(Second) {
+		switch {
+		case rest == 0:
+			return "0s"
+		case rest < uint64(Microsecond):
+			return string(append(appendInt(buf, int(rest), 0), "ns"...))
+		case rest < uint64(Millisecond):
+			return string(append(appendInt(buf, int(rest/uint64(Microsecond)), 0), "µs"...))
+		}
+		return string(append(appendInt(buf, int(rest/uint64(Millisecond)), 0), "ms"...))
+	}
+	start := len(buf)
+	for _, u := range durationUnits {
+		if !u.format || rest < uint64(u.unit) {
+			continue
+		}
+		if len(buf) > start {
+			buf = append(buf, ' ')
+		}
+		buf = appendInt(buf, int(rest/uint64(u.unit)), 0)
+		buf = append(buf, u.compact...)
+		rest %= uint64(u.unit)
+	}
+	return string(buf)
+}
+
+// String returns the compact form ("1h 30m"). See FormatDuration.
+func (d Duration) String() string {
+	return FormatDuration(d, DurationCompact)
+}
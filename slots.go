@@ -0,0 +1,166 @@
+package tinytime
+
+import "slices"
+
+// TimeRange is a daily span of wall-clock time, such as opening hours or a lunch break.
+// A range whose End is not after Start ends on the following day: {18:00, 00:00} runs to
+// midnight and {22:00, 06:00} is a night shift.
+type TimeRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// on returns the range on the local calendar day given as days since 1970-01-01, as UTC instants
+// in the zone described by offset.
+func (r TimeRange) on(days int64, offset func(nano int64) int) Interval {
+	start, end := int64(r.Start), int64(r.End)
+	if end <= start {
+		end += minutesPerDay
+	}
+	local := days * nanosPerDay
+	return Interval{
+		Start: utcFromLocal(local+start*60000000000, offset),
+		End:   utcFromLocal(local+end*60000000000, offset),
+	}
+}
+
+// WeeklySchedule holds the working ranges of each day of the week, indexed by Weekday.
+// Days without ranges are closed.
+//
+//	var ws tinytime.WeeklySchedule
+//	ws[tinytime.Monday] = []tinytime.TimeRange{{Start: 540, End: 1080}} // 09:00-18:00
+type WeeklySchedule [7][]TimeRange
+
+// intervals returns the working ranges in the provider's zone that touch within, minus the daily
+// breaks, merged and clipped to within.
+func (ws *WeeklySchedule) intervals(within Interval, breaks []TimeRange, offset func(nano int64) int) []Interval {
+	if within.IsOpen() || within.IsEmpty() {
+		return nil
+	}
+	firstDay, _ := splitNano(within.Start + int64(offset(within.Start))*1000000000)
+	lastDay, _ := splitNano(within.End - 1 + int64(offset(within.End-1))*1000000000)
+
+	// Start one day early: a night shift that began yesterday can reach into the range
+	var working, blocked []Interval
+	for day := firstDay - 1; day <= lastDay; day++ {
+		for _, r := range ws[weekdayFromDays(day)] {
+			working = append(working, r.on(day, offset))
+		}
+		for _, b := range breaks {
+			blocked = append(blocked, b.on(day, offset))
+		}
+	}
+
+	var clipped []Interval
+	for _, iv := range SubtractIntervals(MergeIntervals(working), blocked) {
+		if part, ok := iv.Intersect(within); ok {
+			clipped = append(clipped, part)
+		}
+	}
+	return clipped
+}
+
+// MergeIntervals returns the intervals sorted by Start with overlapping and touching ones combined,
+// so a list of reservations becomes the busy periods they cover. Empty intervals are dropped.
+func MergeIntervals(intervals []Interval) []Interval {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, iv := range intervals {
+		if !iv.IsEmpty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	slices.SortFunc(sorted, func(a, b Interval) int {
+		switch {
+		case a.Start < b.Start:
+			return -1
+		case a.Start > b.Start:
+			return 1
+		}
+		return 0
+	})
+
+	var merged []Interval
+	for _, iv := range sorted {
+		if n := len(merged); n > 0 {
+			if union, ok := merged[n-1].Union(iv); ok {
+				merged[n-1] = union
+				continue
+			}
+		}
+		merged = append(merged, iv)
+	}
+	return merged
+}
+
+// SubtractIntervals returns the parts of from not covered by any interval in remove,
+// e.g. free time as working hours minus reservations.
+func SubtractIntervals(from, remove []Interval) []Interval {
+	remove = MergeIntervals(remove)
+	var result []Interval
+	for _, iv := range MergeIntervals(from) {
+		cur, end := iv.Start, iv.end()
+		for _, r := range remove {
+			if r.end() <= cur || r.Start >= end {
+				continue
+			}
+			if r.Start > cur {
+				result = append(result, intervalOf(cur, r.Start))
+			}
+			cur = r.end()
+			if cur >= end {
+				break
+			}
+		}
+		if cur < end {
+			result = append(result, intervalOf(cur, end))
+		}
+	}
+	return result
+}
+
+// SlotOptions configures FindSlots.
+type SlotOptions struct {
+	Schedule WeeklySchedule // working hours per weekday
+	Breaks   []TimeRange    // daily breaks removed from every working day (lunch)
+	Busy     []Interval     // existing reservations; open-ended ones block everything after their Start
+	Duration int            // slot length in minutes
+	Buffer   int            // minutes kept free before and after each reservation
+	Step     int            // minutes between consecutive slot starts; 0 uses Duration
+}
+
+// FindSlots returns the free slots of opts.Duration minutes inside within, in chronological order.
+// Working hours and breaks are wall-clock times in the provider's zone. Each free period starts its
+// slots at its own beginning, so the first slot after a reservation begins when the buffer ends.
+// Returns nil for an open-ended or empty range or a non-positive Duration.
+func FindSlots(tp TimeProvider, within Interval, opts SlotOptions) []Interval {
+	if opts.Duration <= 0 {
+		return nil
+	}
+	step := opts.Step
+	if step <= 0 {
+		step = opts.Duration
+	}
+	length := int64(opts.Duration) * 60000000000
+	stepNano := int64(step) * 60000000000
+	buffer := int64(opts.Buffer) * 60000000000
+
+	busy := make([]Interval, 0, len(opts.Busy))
+	for _, b := range opts.Busy {
+		if b.IsEmpty() {
+			continue
+		}
+		padded := Interval{Start: b.Start - buffer}
+		if !b.IsOpen() {
+			padded.End = b.End + buffer
+		}
+		busy = append(busy, padded)
+	}
+
+	var slots []Interval
+	for _, free := range SubtractIntervals(opts.Schedule.intervals(within, opts.Breaks, tp.Offset), busy) {
+		for start := free.Start; start+length <= free.End; start += stepNano {
+			slots = append(slots, Interval{Start: start, End: start + length})
+		}
+	}
+	return slots
+}
@@ -0,0 +1,141 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+const minuteNano = int64(60000000000)
+
+func TestMergeIntervals(t *testing.T) {
+	merged := tinytime.MergeIntervals([]tinytime.Interval{
+		{Start: 500, End: 600},
+		{Start: 100, End: 200},
+		{Start: 150, End: 300},
+		{Start: 300, End: 350}, // touches the previous one
+		{Start: 400, End: 400}, // empty
+		{Start: 550, End: 0},   // open-ended
+	})
+	want := []tinytime.Interval{{Start: 100, End: 350}, {Start: 500, End: 0}}
+	if len(merged) != len(want) {
+		t.Fatalf("MergeIntervals = %v; want %v", merged, want)
+	}
+	for i := range want {
+		if merged[i] != want[i] {
+			t.Errorf("merged[%d] = %v; want %v", i, merged[i], want[i])
+		}
+	}
+}
+
+func TestSubtractIntervals(t *testing.T) {
+	free := tinytime.SubtractIntervals(
+		[]tinytime.Interval{{Start: 0, End: 1000}, {Start: 2000, End: 3000}},
+		[]tinytime.Interval{{Start: 100, End: 200}, {Start: 900, End: 2100}, {Start: 2500, End: 0}},
+	)
+	want := []tinytime.Interval{{Start: 0, End: 100}, {Start: 200, End: 900}, {Start: 2100, End: 2500}}
+	if len(free) != len(want) {
+		t.Fatalf("SubtractIntervals = %v; want %v", free, want)
+	}
+	for i := range want {
+		if free[i] != want[i] {
+			t.Errorf("free[%d] = %v; want %v", i, free[i], want[i])
+		}
+	}
+
+	if got := tinytime.SubtractIntervals([]tinytime.Interval{{Start: 0, End: 100}}, nil); len(got) != 1 || got[0] != (tinytime.Interval{Start: 0, End: 100}) {
+		t.Errorf("SubtractIntervals(nothing) = %v; want [{0 100}]", got)
+	}
+}
+
+func TestFindSlots(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	monday, _ := tp.ParseDate("2024-01-15")
+	at := func(hhmm string) int64 {
+		nano, err := tp.ParseDateTime("2024-01-15", hhmm)
+		if err != nil {
+			t.Fatalf("ParseDateTime(%s) failed: %v", hhmm, err)
+		}
+		return nano
+	}
+
+	var ws tinytime.WeeklySchedule
+	ws[tinytime.Monday] = []tinytime.TimeRange{{Start: 540, End: 1080}} // 09:00-18:00
+
+	opts := tinytime.SlotOptions{
+		Schedule: ws,
+		Breaks:   []tinytime.TimeRange{{Start: 780, End: 840}}, // 13:00-14:00
+		Busy:     []tinytime.Interval{{Start: at("10:00"), End: at("11:00")}},
+		Duration: 60,
+		Buffer:   15,
+	}
+	day := tinytime.Interval{Start: monday, End: tp.EndOfDay(monday) + 1}
+
+	starts := func(slots []tinytime.Interval) []string {
+		var out []string
+		for _, s := range slots {
+			if s.End-s.Start != 60*minuteNano {
+				t.Errorf("slot %s lasts %d minutes; want 60", tp.FormatDateTimeShort(s.Start), (s.End-s.Start)/minuteNano)
+			}
+			out = append(out, tp.FormatTime(s.Start)[:5])
+		}
+		return out
+	}
+	check := func(name string, got, want []string) {
+		if len(got) != len(want) {
+			t.Errorf("%s = %v; want %v", name, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s = %v; want %v", name, got, want)
+				return
+			}
+		}
+	}
+
+	// Free: 09:00-09:45 (too short), 11:15-13:00, 14:00-18:00
+	check("slots", starts(tinytime.FindSlots(tp, day, opts)), []string{"11:15", "14:00", "15:00", "16:00", "17:00"})
+
+	opts.Step = 30
+	check("slots every 30 minutes", starts(tinytime.FindSlots(tp, day, opts)),
+		[]string{"11:15", "11:45", "14:00", "14:30", "15:00", "15:30", "16:00", "16:30", "17:00"})
+
+	// Tuesday is closed and the range is clipped to Monday afternoon
+	opts.Step = 0
+	week := tinytime.Interval{Start: at("15:30"), End: tp.AddDays(monday, 2)}
+	check("clipped range", starts(tinytime.FindSlots(tp, week, opts)), []string{"15:30", "16:30"})
+
+	// An open-ended reservation blocks the rest of the day
+	opts.Busy = append(opts.Busy, tinytime.Interval{Start: at("15:15")}) // buffer starts at 15:00
+	check("open-ended reservation", starts(tinytime.FindSlots(tp, day, opts)), []string{"11:15", "14:00"})
+
+	if got := tinytime.FindSlots(tp, tinytime.Interval{Start: monday}, opts); got != nil {
+		t.Errorf("FindSlots(open range) = %v; want nil", got)
+	}
+	opts.Duration = 0
+	if got := tinytime.FindSlots(tp, day, opts); got != nil {
+		t.Errorf("FindSlots(Duration 0) = %v; want nil", got)
+	}
+}
+
+func TestFindSlots_NightShift(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	wednesday, _ := tp.ParseDate("2024-01-17")
+
+	// Tuesday's 22:00-06:00 shift reaches into Wednesday morning
+	var ws tinytime.WeeklySchedule
+	ws[tinytime.Tuesday] = []tinytime.TimeRange{{Start: 1320, End: 360}}
+
+	slots := tinytime.FindSlots(tp, tinytime.Interval{Start: wednesday, End: tp.AddDays(wednesday, 1)},
+		tinytime.SlotOptions{Schedule: ws, Duration: 120})
+	if len(slots) != 3 {
+		t.Fatalf("FindSlots returned %d slots; want 3", len(slots))
+	}
+	if got := tp.FormatDateTimeShort(slots[0].Start); got != "2024-01-17 00:00" {
+		t.Errorf("first slot = %s; want 2024-01-17 00:00", got)
+	}
+	if got := tp.FormatDateTimeShort(slots[2].End); got != "2024-01-17 06:00" {
+		t.Errorf("last slot ends at %s; want 2024-01-17 06:00", got)
+	}
+}
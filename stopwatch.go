@@ -0,0 +1,67 @@
+package tinytime
+
+// Stopwatch measures elapsed time in nanoseconds with the provider's MonotonicNano, so
+// measurements are not skewed by wall-clock adjustments. It is not safe for concurrent use.
+type Stopwatch struct {
+	tp      TimeProvider
+	started int64 // MonotonicNano when the current run began
+	elapsed int64 // total of the previous runs
+	lapMark int64 // Elapsed at the last lap
+	running bool
+	laps    []int64
+}
+
+// NewStopwatch returns a stopped Stopwatch at zero. Call Start to begin measuring.
+func NewStopwatch(tp TimeProvider) *Stopwatch {
+	return &Stopwatch{tp: tp}
+}
+
+// Start begins or resumes measuring. It does nothing if the stopwatch is running.
+func (sw *Stopwatch) Start() {
+	if sw.running {
+		return
+	}
+	sw.started = sw.tp.MonotonicNano()
+	sw.running = true
+}
+
+// Stop pauses measuring and returns the total elapsed time. Start resumes from it.
+func (sw *Stopwatch) Stop() int64 {
+	if sw.running {
+		sw.elapsed += sw.tp.MonotonicNano() - sw.started
+		sw.running = false
+	}
+	return sw.elapsed
+}
+
+// Lap records a split and returns the time elapsed since the previous lap (or since the start).
+func (sw *Stopwatch) Lap() int64 {
+	now := sw.Elapsed()
+	lap := now - sw.lapMark
+	sw.lapMark = now
+	sw.laps = append(sw.laps, lap)
+	return lap
+}
+
+// Laps returns the recorded splits in order.
+func (sw *Stopwatch) Laps() []int64 {
+	return sw.laps
+}
+
+// Reset stops the stopwatch and clears the elapsed time and laps.
+func (sw *Stopwatch) Reset() {
+	*sw = Stopwatch{tp: sw.tp}
+}
+
+// Elapsed returns the total measured time, including the current run if the stopwatch is running.
+func (sw *Stopwatch) Elapsed() int64 {
+	if sw.running {
+		return sw.elapsed + sw.tp.MonotonicNano() - sw.started
+	}
+	return sw.elapsed
+}
+
+// Running reports whether the stopwatch is measuring.
+func (sw *Stopwatch) Running() bool {
+	return sw.running
+}
@@ -0,0 +1,83 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+func TestMonotonicNano(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	prev := tp.MonotonicNano()
+	for range 1000 {
+		now := tp.MonotonicNano()
+		if now < prev {
+			t.Fatalf("MonotonicNano went backwards: %d < %d", now, prev)
+		}
+		prev = now
+	}
+
+	fake := tinytime.NewFakeTimeProvider(1705307400000000000)
+	start := fake.MonotonicNano()
+	fake.Advance(250)
+	if got := fake.MonotonicNano() - start; got != 250000000 {
+		t.Errorf("fake MonotonicNano advanced %d; want 250000000", got)
+	}
+}
+
+func TestStopwatch(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	sw := tinytime.NewStopwatch(tp)
+
+	tp.Advance(100)
+	if sw.Elapsed() != 0 || sw.Running() {
+		t.Errorf("new stopwatch should be stopped at zero, got %d", sw.Elapsed())
+	}
+
+	sw.Start()
+	tp.Advance(150)
+	if got := sw.Lap(); got != 150000000 {
+		t.Errorf("first Lap() = %d; want 150000000", got)
+	}
+	tp.Advance(50)
+	if got := sw.Lap(); got != 50000000 {
+		t.Errorf("second Lap() = %d; want 50000000", got)
+	}
+	if got := sw.Elapsed(); got != 200000000 {
+		t.Errorf("Elapsed() = %d; want 200000000", got)
+	}
+
+	// Paused time is not counted
+	if got := sw.Stop(); got != 200000000 {
+		t.Errorf("Stop() = %d; want 200000000", got)
+	}
+	tp.Advance(1000)
+	if got := sw.Elapsed(); got != 200000000 {
+		t.Errorf("Elapsed() while stopped = %d; want 200000000", got)
+	}
+	sw.Start()
+	sw.Start() // no-op while running
+	tp.Advance(30)
+	if got := sw.Lap(); got != 30000000 {
+		t.Errorf("Lap() after resume = %d; want 30000000", got)
+	}
+	if got := sw.Stop(); got != 230000000 {
+		t.Errorf("Stop() after resume = %d; want 230000000", got)
+	}
+
+	laps := sw.Laps()
+	if len(laps) != 3 || laps[0] != 150000000 || laps[1] != 50000000 || laps[2] != 30000000 {
+		t.Errorf("Laps() = %v; want [150000000 50000000 30000000]", laps)
+	}
+
+	sw.Reset()
+	if sw.Elapsed() != 0 || sw.Running() || len(sw.Laps()) != 0 {
+		t.Error("Reset() should clear elapsed time, laps and stop the stopwatch")
+	}
+	sw.Start()
+	tp.Advance(10)
+	if got := sw.Elapsed(); got != 10000000 {
+		t.Errorf("Elapsed() after Reset = %d; want 10000000", got)
+	}
+}
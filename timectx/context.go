@@ -0,0 +1,228 @@
+// Package timectx ties context.Context to a tinytime.TimeProvider: timers that stop with a context,
+// deadlines on the provider's clock and a cancellable Sleep. It lives outside the root package so
+// WASM binaries that do not need it never link the context and time packages.
+package timectx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// maxTimerDelay keeps each provider timer within the 32-bit millisecond limit of setTimeout
+// (about 24.8 days), which fires longer delays immediately; longer waits are covered by re-arming.
+const maxTimerDelay = 1<<31 - 1
+
+// AfterFunc is like tp.AfterFunc but the timer is stopped when ctx is done,
+// so callbacks tied to a request never run after the request ends. f does not run
+// if ctx is already done, and Reset does not re-arm the timer once ctx is done.
+func AfterFunc(ctx context.Context, tp tinytime.TimeProvider, milliseconds int, f func()) tinytime.Timer {
+	ct := &ctxTimer{ctx: ctx, tp: tp, f: f}
+	ct.mu.Lock()
+	ct.timer = tp.AfterFunc(ct.chunk(milliseconds), ct.fire)
+	ct.watch()
+	ct.mu.Unlock()
+	return ct
+}
+
+// ctxTimer implements tinytime.Timer for AfterFunc
+type ctxTimer struct {
+	ctx       context.Context
+	tp        tinytime.TimeProvider
+	f         func()
+	mu        sync.Mutex
+	timer     tinytime.Timer
+	remaining int         // milliseconds left to wait after the current timer
+	unwatch   func() bool // releases the context.AfterFunc registration
+}
+
+// chunk returns the delay of the next timer and records what is left. Caller must hold ct.mu.
+func (ct *ctxTimer) chunk(milliseconds int) int {
+	delay := min(max(milliseconds, 0), maxTimerDelay)
+	ct.remaining = milliseconds - delay
+	return delay
+}
+
+// watch stops the timer when ctx is done. Caller must hold ct.mu.
+func (ct *ctxTimer) watch() {
+	ct.unwatch = context.AfterFunc(ct.ctx, func() {
+		ct.mu.Lock()
+		defer ct.mu.Unlock()
+		ct.timer.Stop()
+	})
+}
+
+// release drops the context registration. Caller must hold ct.mu.
+func (ct *ctxTimer) release() {
+	if ct.unwatch != nil {
+		ct.unwatch()
+		ct.unwatch = nil
+	}
+}
+
+func (ct *ctxTimer) fire() {
+	ct.mu.Lock()
+	if ct.remaining > 0 && ct.ctx.Err() == nil {
+		ct.timer = ct.tp.AfterFunc(ct.chunk(ct.remaining), ct.fire)
+		ct.mu.Unlock()
+		return
+	}
+	ct.release()
+	ct.mu.Unlock()
+
+	if ct.ctx.Err() == nil && ct.f != nil {
+		ct.f()
+	}
+}
+
+func (ct *ctxTimer) Stop() bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	ct.release()
+	return ct.timer.Stop()
+}
+
+func (ct *ctxTimer) Reset(milliseconds int) bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	if ct.ctx.Err() != nil {
+		return false
+	}
+	wasActive := ct.timer.Reset(ct.chunk(milliseconds))
+	if ct.unwatch == nil {
+		ct.watch()
+	}
+	return wasActive
+}
+
+func (ct *ctxTimer) Active() bool {
+	ct.mu.Lock()
+	defer ct.mu.Unlock()
+	return ct.timer.Active()
+}
+
+// WithDeadline returns a copy of parent that is cancelled with context.DeadlineExceeded when the
+// provider's clock reaches the deadline (UnixNano), so a tinytime.FakeTimeProvider can expire it with Advance.
+// Calling the returned CancelFunc releases the timer; call it as soon as the work is done.
+func WithDeadline(parent context.Context, tp tinytime.TimeProvider, deadline int64) (context.Context, context.CancelFunc) {
+	dc := &deadlineCtx{parent: parent, deadline: deadline, done: make(chan struct{})}
+	cancel := func() { dc.cancel(context.Canceled) }
+	if err := parent.Err(); err != nil {
+		dc.cancel(err)
+		return dc, cancel
+	}
+	delay := deadline - tp.UnixNano()
+	if delay <= 0 {
+		dc.cancel(context.DeadlineExceeded)
+		return dc, cancel
+	}
+
+	dc.mu.Lock()
+	dc.arm(tp)
+	dc.unwatch = context.AfterFunc(parent, func() { dc.cancel(parent.Err()) })
+	dc.mu.Unlock()
+	return dc, cancel
+}
+
+// WithTimeout is WithDeadline at the given milliseconds from the provider's current time.
+func WithTimeout(parent context.Context, tp tinytime.TimeProvider, milliseconds int) (context.Context, context.CancelFunc) {
+	return WithDeadline(parent, tp, tp.UnixNano()+int64(milliseconds)*1000000)
+}
+
+// deadlineCtx is a context cancelled by a provider timer. It keeps its own done channel and error
+// instead of wrapping a context.WithCancel, so contexts derived from it with the context package
+// inherit context.DeadlineExceeded rather than context.Canceled.
+type deadlineCtx struct {
+	parent   context.Context
+	deadline int64
+	done     chan struct{}
+
+	mu      sync.Mutex
+	err     error
+	timer   tinytime.Timer
+	unwatch func() bool // releases the context.AfterFunc registration on parent
+}
+
+// arm waits for the deadline, at most maxTimerDelay at a time. Caller must hold dc.mu.
+func (dc *deadlineCtx) arm(tp tinytime.TimeProvider) {
+	delay := (dc.deadline - tp.UnixNano() + 999999) / 1000000
+	dc.timer = tp.AfterFunc(int(min(max(delay, 0), maxTimerDelay)), func() { dc.expire(tp) })
+}
+
+// expire cancels the context once the provider's clock has reached the deadline, and re-arms
+// the timer when it woke up early for a capped long delay.
+func (dc *deadlineCtx) expire(tp tinytime.TimeProvider) {
+	dc.mu.Lock()
+	if dc.err == nil && tp.UnixNano() < dc.deadline {
+		dc.arm(tp)
+		dc.mu.Unlock()
+		return
+	}
+	dc.mu.Unlock()
+	dc.cancel(context.DeadlineExceeded)
+}
+
+// cancel closes done with err; only the first call has an effect.
+func (dc *deadlineCtx) cancel(err error) {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	if dc.err != nil {
+		return
+	}
+	dc.err = err
+	close(dc.done)
+	if dc.timer != nil {
+		dc.timer.Stop()
+	}
+	if dc.unwatch != nil {
+		dc.unwatch()
+	}
+}
+
+func (dc *deadlineCtx) Deadline() (time.Time, bool) {
+	deadline := time.Unix(0, dc.deadline)
+	if parent, ok := dc.parent.Deadline(); ok && parent.Before(deadline) {
+		return parent, true
+	}
+	return deadline, true
+}
+
+func (dc *deadlineCtx) Done() <-chan struct{} {
+	return dc.done
+}
+
+func (dc *deadlineCtx) Err() error {
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	return dc.err
+}
+
+func (dc *deadlineCtx) Value(key any) any {
+	return dc.parent.Value(key)
+}
+
+// Sleep pauses the calling goroutine for the given milliseconds of the provider's clock, or until
+// ctx is done, in which case it returns ctx.Err(). In WASM the wait is a setTimeout, so the JS event
+// loop keeps running while the goroutine is parked; as with any blocking call, do not call it
+// directly from a js.FuncOf callback.
+func Sleep(ctx context.Context, tp tinytime.TimeProvider, milliseconds int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for {
+		delay := min(max(milliseconds, 0), maxTimerDelay)
+		done := make(chan struct{})
+		timer := tp.AfterFunc(delay, func() { close(done) })
+		select {
+		case <-done:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+		if milliseconds -= delay; milliseconds <= 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package timectx_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/cdvelop/tinytime"
+	"github.com/cdvelop/tinytime/timectx"
+)
+
+func TestAfterFunc(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+	count := 0
+
+	// Runs normally while the context is alive
+	timer := timectx.AfterFunc(context.Background(), tp, 100, func() { count++ })
+	if !timer.Active() {
+		t.Error("Active() should return true for armed timer")
+	}
+	tp.Advance(100)
+	if count != 1 {
+		t.Errorf("count = %d; want 1", count)
+	}
+
+	// Cancelling the context stops the timer
+	ctx, cancel := context.WithCancel(context.Background())
+	timer = timectx.AfterFunc(ctx, tp, 100, func() { count++ })
+	cancel()
+	tp.Advance(100)
+	if count != 1 {
+		t.Errorf("callback ran after context was cancelled: count = %d", count)
+	}
+	if timer.Reset(100) {
+		t.Error("Reset() should return false once the context is done")
+	}
+	tp.Advance(100)
+	if count != 1 {
+		t.Errorf("Reset re-armed a timer of a done context: count = %d", count)
+	}
+
+	// Stop and Reset behave like AfterFunc while the context is alive
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	timer = timectx.AfterFunc(ctx, tp, 100, func() { count++ })
+	if !timer.Stop() || timer.Stop() {
+		t.Error("Stop() should return true once, then false")
+	}
+	if timer.Reset(50) {
+		t.Error("Reset() should return false for stopped timer")
+	}
+	tp.Advance(50)
+	if count != 2 {
+		t.Errorf("count after Reset = %d; want 2", count)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(1705307400000000000)
+
+	ctx, cancel := timectx.WithTimeout(context.Background(), tp, 5000)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || deadline.UnixNano() != 1705307405000000000 {
+		t.Errorf("Deadline() = %v, %v; want provider time + 5s", deadline.UnixNano(), ok)
+	}
+
+	tp.Advance(4999)
+	if ctx.Err() != nil {
+		t.Fatalf("context expired early: %v", ctx.Err())
+	}
+	tp.Advance(1)
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() = %v; want context.DeadlineExceeded", ctx.Err())
+	}
+
+	// Contexts derived with the context package inherit context.DeadlineExceeded
+	ctx, cancel = timectx.WithTimeout(context.Background(), tp, 5000)
+	defer cancel()
+	child, cancelChild := context.WithCancel(ctx)
+	defer cancelChild()
+	grandchild, cancelGrandchild := context.WithTimeout(child, time.Hour)
+	defer cancelGrandchild()
+	tp.Advance(5000)
+	<-grandchild.Done()
+	if !errors.Is(child.Err(), context.DeadlineExceeded) || !errors.Is(grandchild.Err(), context.DeadlineExceeded) {
+		t.Errorf("derived Err() = %v, %v; want context.DeadlineExceeded", child.Err(), grandchild.Err())
+	}
+
+	// Cancelling first reports context.Canceled and releases the timer
+	ctx, cancel = timectx.WithTimeout(context.Background(), tp, 5000)
+	cancel()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Err() after cancel = %v; want context.Canceled", ctx.Err())
+	}
+	if tp.Pending() != 0 {
+		t.Errorf("Pending() after cancel = %d; want 0", tp.Pending())
+	}
+
+	// A deadline already in the past expires immediately
+	ctx, cancel = timectx.WithDeadline(context.Background(), tp, tp.UnixNano()-1)
+	defer cancel()
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Errorf("Err() for past deadline = %v; want context.DeadlineExceeded", ctx.Err())
+	}
+
+	// Parent cancellation propagates
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel = timectx.WithTimeout(parent, tp, 5000)
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Err() after parent cancel = %v; want context.Canceled", ctx.Err())
+	}
+}
+
+func TestSleep(t *testing.T) {
+	tp := tinytime.NewFakeTimeProvider(0)
+
+	done := make(chan error)
+	go func() { done <- timectx.Sleep(context.Background(), tp, 1000) }()
+	for tp.Pending() == 0 { // wait until Sleep armed its timer
+		runtime.Gosched()
+	}
+	tp.Advance(1000)
+	if err := <-done; err != nil {
+		t.Errorf("Sleep() = %v; want nil", err)
+	}
+
+	// Context expiry on the same fake clock interrupts the sleep
+	ctx, cancel := timectx.WithTimeout(context.Background(), tp, 500)
+	defer cancel()
+	go func() { done <- timectx.Sleep(ctx, tp, 1000) }()
+	for tp.Pending() < 2 {
+		runtime.Gosched()
+	}
+	tp.Advance(500)
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Sleep() = %v; want context.DeadlineExceeded", err)
+	}
+	if err := timectx.Sleep(ctx, tp, 1000); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Sleep(done context) = %v; want context.DeadlineExceeded", err)
+	}
+}
+
+// cappedProvider records the longest delay requested from AfterFunc.
+type cappedProvider struct {
+	*tinytime.FakeTimeProvider
+	longest int
+}
+
+func (cp *cappedProvider) AfterFunc(milliseconds int, f func()) tinytime.Timer {
+	cp.longest = max(cp.longest, milliseconds)
+	return cp.FakeTimeProvider.AfterFunc(milliseconds, f)
+}
+
+// setTimeout fires delays over 2^31-1 ms immediately, so long waits must be re-armed in pieces.
+func TestLongDelays(t *testing.T) {
+	const maxDelay = 1<<31 - 1
+	const days30 = 30 * 24 * 3600 * 1000
+	tp := &cappedProvider{FakeTimeProvider: tinytime.NewFakeTimeProvider(0)}
+
+	ctx, cancel := timectx.WithTimeout(context.Background(), tp, days30)
+	defer cancel()
+	count := 0
+	timectx.AfterFunc(context.Background(), tp, days30, func() { count++ })
+	done := make(chan error)
+	go func() { done <- timectx.Sleep(context.Background(), tp, days30) }()
+	for tp.Pending() < 3 {
+		runtime.Gosched()
+	}
+
+	tp.Advance(maxDelay)
+	if ctx.Err() != nil || count != 0 {
+		t.Fatalf("30-day timers fired after %d ms: Err() = %v, count = %d", maxDelay, ctx.Err(), count)
+	}
+	for tp.Pending() < 3 { // Sleep re-arms from its own goroutine
+		runtime.Gosched()
+	}
+	tp.Advance(days30 - maxDelay - 1)
+	if ctx.Err() != nil || count != 0 {
+		t.Fatalf("30-day timers fired 1 ms early: Err() = %v, count = %d", ctx.Err(), count)
+	}
+	tp.Advance(1)
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) || count != 1 {
+		t.Errorf("after 30 days: Err() = %v, count = %d; want DeadlineExceeded, 1", ctx.Err(), count)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("Sleep(30 days) = %v; want nil", err)
+	}
+	if tp.longest > maxDelay {
+		t.Errorf("longest AfterFunc delay = %d ms; want at most %d", tp.longest, maxDelay)
+	}
+}
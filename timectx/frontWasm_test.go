@@ -0,0 +1,25 @@
+//go:build wasm
+
+package timectx_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+	"github.com/cdvelop/tinytime/timectx"
+)
+
+func TestSleep_YieldsToEventLoop(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	// A setTimeout scheduled before the sleep must run while the goroutine is parked
+	ran := false
+	tp.AfterFunc(1, func() { ran = true })
+	if err := timectx.Sleep(context.Background(), tp, 20); err != nil {
+		t.Fatalf("Sleep() = %v; want nil", err)
+	}
+	if !ran {
+		t.Error("event loop did not run during Sleep")
+	}
+}
@@ -0,0 +1,105 @@
+package tinytime
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// minutesPerDay is the number of distinct TimeOfDay values.
+const minutesPerDay = 24 * 60
+
+// TimeOfDay is a wall-clock time without date or zone, stored as minutes since midnight (0-1439).
+// It matches the int16 columns used for opening hours and work shifts.
+type TimeOfDay int16
+
+// NewTimeOfDay returns the TimeOfDay for hour (0-23) and minute (0-59).
+func NewTimeOfDay(hour, minute int) (TimeOfDay, error) {
+	if hour < 0 || hour > 23 {
+		return 0, Errf("invalid hours: %d", hour)
+	}
+	if minute < 0 || minute > 59 {
+		return 0, Errf("invalid minutes: %d", minute)
+	}
+	return TimeOfDay(hour*60 + minute), nil
+}
+
+// TimeOfDayFromMinutes validates a stored minutes-since-midnight value (0-1439).
+func TimeOfDayFromMinutes(minutes int) (TimeOfDay, error) {
+	if minutes < 0 || minutes >= minutesPerDay {
+		return 0, Errf("invalid minutes since midnight: %d", minutes)
+	}
+	return TimeOfDay(minutes), nil
+}
+
+// Valid reports whether t is within 00:00-23:59.
+func (t TimeOfDay) Valid() bool {
+	return t >= 0 && t < minutesPerDay
+}
+
+// Hour returns the hour (0-23).
+func (t TimeOfDay) Hour() int {
+	return int(t) / 60
+}
+
+// Minute returns the minute within the hour (0-59).
+func (t TimeOfDay) Minute() int {
+	return int(t) % 60
+}
+
+// Minutes returns the minutes since midnight.
+func (t TimeOfDay) Minutes() int {
+	return int(t)
+}
+
+// Add returns t shifted by the given minutes (negative subtracts), wrapping around midnight:
+// 23:30 plus 45 minutes is 00:15.
+func (t TimeOfDay) Add(minutes int) TimeOfDay {
+	m := (int(t) + minutes) % minutesPerDay
+	if m < 0 {
+		m += minutesPerDay
+	}
+	return TimeOfDay(m)
+}
+
+// Sub returns the minutes from u forward to t, wrapping around midnight, in the range 0-1439.
+// A shift from 22:00 to 06:00 lasts 06:00.Sub(22:00) = 480 minutes.
+func (t TimeOfDay) Sub(u TimeOfDay) int {
+	m := (int(t) - int(u)) % minutesPerDay
+	if m < 0 {
+		m += minutesPerDay
+	}
+	return m
+}
+
+// Before reports whether t is earlier in the day than u.
+func (t TimeOfDay) Before(u TimeOfDay) bool {
+	return t < u
+}
+
+// After reports whether t is later in the day than u.
+func (t TimeOfDay) After(u TimeOfDay) bool {
+	return t > u
+}
+
+// String returns the time as "HH:MM", or "" if t is out of range.
+func (t TimeOfDay) String() string {
+	if !t.Valid() {
+		return ""
+	}
+	buf := appendInt(make([]byte, 0, 5), t.Hour(), 2)
+	buf = append(buf, ':')
+	return string(appendInt(buf, t.Minute(), 2))
+}
+
+// Format12 returns the time in 12-hour format ("8:30 AM", "12:00 PM"), or "" if t is out of range.
+func (t TimeOfDay) Format12() string {
+	if !t.Valid() {
+		return ""
+	}
+	buf := appendInt(make([]byte, 0, 8), hour12(t.Hour()), 1)
+	buf = append(buf, ':')
+	buf = appendInt(buf, t.Minute(), 2)
+	if t.Hour() < 12 {
+		return string(append(buf, " AM"...))
+	}
+	return string(append(buf, " PM"...))
+}
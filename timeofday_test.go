@@ -0,0 +1,154 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+func TestTimeOfDay(t *testing.T) {
+	tod, err := tinytime.NewTimeOfDay(8, 30)
+	if err != nil {
+		t.Fatalf("NewTimeOfDay(8, 30) failed: %v", err)
+	}
+	if tod != 510 || tod.Hour() != 8 || tod.Minute() != 30 || tod.Minutes() != 510 {
+		t.Errorf("NewTimeOfDay(8, 30) = %d (%d:%d); want 510", tod, tod.Hour(), tod.Minute())
+	}
+	if tod.String() != "08:30" {
+		t.Errorf("String() = %s; want 08:30", tod.String())
+	}
+
+	for _, invalid := range [][2]int{{24, 0}, {-1, 30}, {8, 60}, {8, -1}} {
+		if _, err := tinytime.NewTimeOfDay(invalid[0], invalid[1]); err == nil {
+			t.Errorf("NewTimeOfDay(%d, %d) should return error", invalid[0], invalid[1])
+		}
+	}
+
+	if _, err := tinytime.TimeOfDayFromMinutes(1439); err != nil {
+		t.Errorf("TimeOfDayFromMinutes(1439) failed: %v", err)
+	}
+	for _, invalid := range []int{-90, 1440, 32767} {
+		if _, err := tinytime.TimeOfDayFromMinutes(invalid); err == nil {
+			t.Errorf("TimeOfDayFromMinutes(%d) should return error", invalid)
+		}
+	}
+}
+
+func TestTimeOfDay_Arithmetic(t *testing.T) {
+	late, _ := tinytime.NewTimeOfDay(23, 30)
+	early, _ := tinytime.NewTimeOfDay(6, 0)
+
+	if got := late.Add(45).String(); got != "00:15" {
+		t.Errorf("23:30 + 45 = %s; want 00:15", got)
+	}
+	if got := early.Add(-390).String(); got != "23:30" {
+		t.Errorf("06:00 - 390 = %s; want 23:30", got)
+	}
+	if got := early.Add(3 * 1440).String(); got != "06:00" {
+		t.Errorf("06:00 + 3 days = %s; want 06:00", got)
+	}
+
+	// Night shift 22:00-06:00 wraps past midnight
+	start, _ := tinytime.NewTimeOfDay(22, 0)
+	if got := early.Sub(start); got != 480 {
+		t.Errorf("06:00.Sub(22:00) = %d; want 480", got)
+	}
+	if got := late.Sub(early); got != 1050 {
+		t.Errorf("23:30.Sub(06:00) = %d; want 1050", got)
+	}
+	if got := early.Sub(early); got != 0 {
+		t.Errorf("Sub(self) = %d; want 0", got)
+	}
+
+	if !early.Before(late) || early.After(late) || !late.After(early) || early.Before(early) {
+		t.Error("Before/After returned unexpected ordering")
+	}
+}
+
+func TestTimeOfDay_Format(t *testing.T) {
+	tests := []struct {
+		hour, minute int
+		want         string
+	}{
+		{0, 0, "12:00 AM"},
+		{0, 5, "12:05 AM"},
+		{8, 30, "8:30 AM"},
+		{12, 0, "12:00 PM"},
+		{13, 45, "1:45 PM"},
+		{23, 59, "11:59 PM"},
+	}
+	for _, tt := range tests {
+		tod, _ := tinytime.NewTimeOfDay(tt.hour, tt.minute)
+		if got := tod.Format12(); got != tt.want {
+			t.Errorf("Format12(%02d:%02d) = %s; want %s", tt.hour, tt.minute, got, tt.want)
+		}
+	}
+
+	// Out-of-range values never render as garbage like "-1:-30"
+	for _, invalid := range []tinytime.TimeOfDay{-90, 1440} {
+		if invalid.Valid() || invalid.String() != "" || invalid.Format12() != "" {
+			t.Errorf("TimeOfDay(%d) should be invalid and format as empty", int(invalid))
+		}
+	}
+}
+
+func TestTimeOfDay_Provider(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+
+	tod, err := tp.ParseTime("17:45")
+	if err != nil {
+		t.Fatalf("ParseTime(17:45) failed: %v", err)
+	}
+	if tod.Hour() != 17 || tod.Minute() != 45 {
+		t.Errorf("ParseTime(17:45) = %d:%d; want 17:45", tod.Hour(), tod.Minute())
+	}
+	if got := tp.FormatTime(tod); got != "17:45" {
+		t.Errorf("FormatTime(TimeOfDay) = %s; want 17:45", got)
+	}
+	if got := tp.FormatTime(int16(-90)); got != "" {
+		t.Errorf("FormatTime(int16(-90)) = %q; want empty", got)
+	}
+	if got := tp.FormatTime(int16(1440)); got != "" {
+		t.Errorf("FormatTime(int16(1440)) = %q; want empty", got)
+	}
+}
+
+func ParseTimeSecondsShared(t *testing.T, tp tinytime.TimeProvider) {
+	valid := []struct {
+		input string
+		want  int32
+	}{
+		{"08:30:45", 30645},
+		{"08:30", 30600},
+		{"00:00:00", 0},
+		{"23:59:59", 86399},
+		{"08:30:45.123", 30645},
+		{"08:30:45,999999999", 30645},
+	}
+	for _, tt := range valid {
+		got, err := tp.ParseTimeSeconds(tt.input)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTimeSeconds(%q) = %d, %v; want %d", tt.input, got, err, tt.want)
+		}
+	}
+
+	invalid := []string{
+		"08:30:99", "08:60:00", "24:00:00", "8:30:45", "08:30:4", "08:30:45.",
+		"08:30:45.1234567890", "08:30:45Z", "08:30:", "08", "", "ab:cd:ef", "-1:30:00",
+	}
+	for _, input := range invalid {
+		if _, err := tp.ParseTimeSeconds(input); err == nil {
+			t.Errorf("ParseTimeSeconds(%q) should return error", input)
+		}
+	}
+
+	if got := tp.FormatTime(int32(30645)); got != "08:30:45" {
+		t.Errorf("FormatTime(int32(30645)) = %s; want 08:30:45", got)
+	}
+	if got := tp.FormatTime(int32(86400)); got != "" {
+		t.Errorf("FormatTime(int32(86400)) = %q; want empty", got)
+	}
+	if got := tp.FormatTime(int32(-1)); got != "" {
+		t.Errorf("FormatTime(int32(-1)) = %q; want empty", got)
+	}
+}
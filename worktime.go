@@ -0,0 +1,78 @@
+package tinytime
+
+// WorkingHours defines when working time runs, e.g. for ticket SLAs: the weekly schedule minus
+// the daily breaks and the dates of holidays.
+type WorkingHours struct {
+	Schedule WeeklySchedule    // working ranges per weekday in the provider's zone
+	Breaks   []TimeRange       // daily breaks that do not count (lunch)
+	Holidays *BusinessCalendar // dates it reports as holidays are not worked (its weekend is not used); nil for none
+}
+
+// workWindowDays is the span of the chunks in which working time is computed, so long ranges never
+// expand to one big list of intervals.
+const workWindowDays = 7
+
+// intervals returns the working periods inside within in the provider's zone.
+func (wh WorkingHours) intervals(within Interval, offset func(nano int64) int) []Interval {
+	working := wh.Schedule.intervals(within, wh.Breaks, offset)
+	if wh.Holidays == nil || len(working) == 0 {
+		return working
+	}
+	firstDay, _ := splitNano(within.Start + int64(offset(within.Start))*1000000000)
+	lastDay, _ := splitNano(within.End - 1 + int64(offset(within.End-1))*1000000000)
+	var closed []Interval
+	for day := firstDay; day <= lastDay; day++ {
+		if wh.Holidays.isHoliday(day) {
+			closed = append(closed, Interval{
+				Start: utcFromLocal(day*nanosPerDay, offset),
+				End:   utcFromLocal((day+1)*nanosPerDay, offset),
+			})
+		}
+	}
+	return SubtractIntervals(working, closed)
+}
+
+// Between returns the working time from one UnixNano to another in the provider's zone, e.g. the
+// time a ticket has been open within business hours. It is negative when to is before from.
+func (wh WorkingHours) Between(tp TimeProvider, from, to int64) Duration {
+	if to < from {
+		return -wh.Between(tp, to, from)
+	}
+	var total int64
+	for start := from; start < to; start += workWindowDays * nanosPerDay {
+		end := min(start+workWindowDays*nanosPerDay, to)
+		for _, iv := range wh.intervals(Interval{Start: start, End: end}, tp.Offset) {
+			total += iv.End - iv.Start
+		}
+	}
+	return Duration(total)
+}
+
+// Deadline returns the UnixNano at which the given working minutes have elapsed after start, e.g. the
+// due time of a ticket with an 8-hour resolution SLA. Counting begins at the next working period when
+// start falls outside working hours, and a deadline that lands exactly at the end of a period is not
+// moved to the next one. minutes <= 0 returns start. Returns 0 if there is no working time within a year.
+func (wh WorkingHours) Deadline(tp TimeProvider, start int64, minutes int) int64 {
+	if minutes <= 0 {
+		return start
+	}
+	remaining := int64(minutes) * 60000000000
+	for empty := 0; empty*workWindowDays < maxNonBusinessDays; {
+		end := start + workWindowDays*nanosPerDay
+		working := wh.intervals(Interval{Start: start, End: end}, tp.Offset)
+		if len(working) == 0 {
+			empty++ // consecutive windows without working time
+		} else {
+			empty = 0
+		}
+		for _, iv := range working {
+			if length := iv.End - iv.Start; remaining > length {
+				remaining -= length
+				continue
+			}
+			return iv.Start + remaining
+		}
+		start = end
+	}
+	return 0
+}
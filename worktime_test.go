@@ -0,0 +1,114 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// officeHours is Monday to Friday 09:00-18:00 with a 13:00-14:00 lunch break (8 working hours a day),
+// closed on Good Friday and Christmas.
+func officeHours(tp tinytime.TimeProvider) tinytime.WorkingHours {
+	var ws tinytime.WeeklySchedule
+	for d := tinytime.Monday; d <= tinytime.Friday; d++ {
+		ws[d] = []tinytime.TimeRange{{Start: 540, End: 1080}}
+	}
+	return tinytime.WorkingHours{
+		Schedule: ws,
+		Breaks:   []tinytime.TimeRange{{Start: 780, End: 840}},
+		Holidays: tinytime.NewBusinessCalendar(tp, nil, tinytime.EasterHoliday(-2), tinytime.FixedHoliday(12, 25)),
+	}
+}
+
+func TestWorkingHours_Between(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	wh := officeHours(tp)
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	tests := []struct {
+		from, to string
+		want     tinytime.Duration
+	}{
+		{"2024-01-15 10:00", "2024-01-15 11:30", 90 * tinytime.Minute},
+		{"2024-01-15 12:30", "2024-01-15 14:30", 60 * tinytime.Minute},    // lunch does not count
+		{"2024-01-15 17:00", "2024-01-16 10:00", 2 * tinytime.Hour},       // overnight
+		{"2024-01-19 17:00", "2024-01-22 09:30", 90 * tinytime.Minute},    // over the weekend
+		{"2024-03-28 17:00", "2024-04-01 10:00", 2 * tinytime.Hour},       // over Good Friday
+		{"2024-01-15 00:00", "2024-01-22 00:00", 40 * tinytime.Hour},      // one week
+		{"2024-01-01 00:00", "2025-01-01 00:00", 260 * 8 * tinytime.Hour}, // 262 weekdays minus 2 holidays
+		{"2024-01-16 10:00", "2024-01-15 17:00", -2 * tinytime.Hour},      // reversed
+		{"2024-01-20 08:00", "2024-01-20 20:00", 0},                       // Saturday
+		{"2024-01-15 18:30", "2024-01-15 18:30", 0},
+	}
+	for _, tt := range tests {
+		if got := wh.Between(tp, parse(tt.from), parse(tt.to)); got != tt.want {
+			t.Errorf("Between(%s, %s) = %s; want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestWorkingHours_Deadline(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	wh := officeHours(tp)
+	parse := func(s string) int64 {
+		nano, err := tp.Parse(s)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %v", s, err)
+		}
+		return nano
+	}
+
+	tests := []struct {
+		start   string
+		minutes int
+		want    string
+	}{
+		{"2024-01-15 10:00", 60, "2024-01-15 11:00"},
+		{"2024-01-15 12:30", 60, "2024-01-15 14:30"},  // paused during lunch
+		{"2024-01-15 16:00", 120, "2024-01-15 18:00"}, // ends exactly at closing time
+		{"2024-01-15 17:00", 120, "2024-01-16 10:00"},
+		{"2024-01-20 10:00", 30, "2024-01-22 09:30"},         // opened on a Saturday
+		{"2024-03-28 17:00", 120, "2024-04-01 10:00"},        // over Good Friday
+		{"2024-12-20 09:00", 5 * 8 * 60, "2024-12-27 18:00"}, // five working days over Christmas
+		{"2024-01-15 10:00", 0, "2024-01-15 10:00"},
+	}
+	for _, tt := range tests {
+		if got := tp.FormatDateTimeShort(wh.Deadline(tp, parse(tt.start), tt.minutes)); got != tt.want {
+			t.Errorf("Deadline(%s, %d) = %s; want %s", tt.start, tt.minutes, got, tt.want)
+		}
+	}
+
+	// Between measures exactly the minutes Deadline adds
+	start := parse("2024-03-25 07:45")
+	for _, minutes := range []int{1, 45, 240, 481, 2400, 10000} {
+		deadline := wh.Deadline(tp, start, minutes)
+		if got := wh.Between(tp, start, deadline); got != tinytime.Duration(minutes)*tinytime.Minute {
+			t.Errorf("Between(start, Deadline(%d)) = %s", minutes, got)
+		}
+	}
+
+	if got := (tinytime.WorkingHours{}).Deadline(tp, start, 60); got != 0 {
+		t.Errorf("Deadline without working hours = %d; want 0", got)
+	}
+}
+
+func TestWorkingHours_NightShift(t *testing.T) {
+	tp := tinytime.NewTimeProvider()
+	var ws tinytime.WeeklySchedule
+	ws[tinytime.Monday] = []tinytime.TimeRange{{Start: 1320, End: 360}} // 22:00-06:00
+	wh := tinytime.WorkingHours{Schedule: ws}
+
+	monday, _ := tp.Parse("2024-01-15 21:00")
+	if got := wh.Between(tp, monday, monday+10*60*minuteNano); got != 8*tinytime.Hour {
+		t.Errorf("Between(night shift) = %s; want 8h0m0s", got)
+	}
+	if got := tp.FormatDateTimeShort(wh.Deadline(tp, monday, 7*60)); got != "2024-01-16 05:00" {
+		t.Errorf("Deadline(night shift) = %s; want 2024-01-16 05:00", got)
+	}
+}
@@ -0,0 +1,479 @@
+package tinytime_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/tinytime"
+)
+
+// Zone tests run unchanged on both builds: timeServer uses time.LoadLocation and
+// timeClient uses Intl.DateTimeFormat, so both must agree on every value below.
+
+func TestTimeProviderInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	if tp.Zone() != "America/Santiago" {
+		t.Errorf("Zone() = %s; want America/Santiago", tp.Zone())
+	}
+
+	summer := int64(1705307400000000000) // 2024-01-15 08:30:00 UTC (UTC-3 in Santiago)
+	winter := int64(1721044800000000000) // 2024-07-15 12:00:00 UTC (UTC-4 in Santiago)
+
+	if off := tp.Offset(summer); off != -3*3600 {
+		t.Errorf("Offset(summer) = %d; want %d", off, -3*3600)
+	}
+	if off := tp.Offset(winter); off != -4*3600 {
+		t.Errorf("Offset(winter) = %d; want %d", off, -4*3600)
+	}
+
+	if result := tp.FormatDateTime(summer); result != "2024-01-15 05:30:00" {
+		t.Errorf("FormatDateTime(summer) = %s; want 2024-01-15 05:30:00", result)
+	}
+	if result := tp.FormatDateTimeShort(winter); result != "2024-07-15 08:00" {
+		t.Errorf("FormatDateTimeShort(winter) = %s; want 2024-07-15 08:00", result)
+	}
+	if result := tp.FormatTime(summer); result != "05:30:00" {
+		t.Errorf("FormatTime(summer) = %s; want 05:30:00", result)
+	}
+
+	// Late evening UTC-3 is already the next day in UTC
+	lateEvening := int64(1705370400000000000) // 2024-01-16 02:00:00 UTC
+	if result := tp.FormatDate(lateEvening); result != "2024-01-15" {
+		t.Errorf("FormatDate(lateEvening) = %s; want 2024-01-15", result)
+	}
+
+	nano, err := tp.ParseDate("2024-01-15")
+	if err != nil {
+		t.Errorf("ParseDate(2024-01-15) failed: %v", err)
+	}
+	if nano != 1705287600000000000 { // 2024-01-15 03:00:00 UTC
+		t.Errorf("ParseDate(2024-01-15) = %d; want 1705287600000000000", nano)
+	}
+
+	nano, err = tp.ParseDateTime("2024-07-15", "08:00")
+	if err != nil {
+		t.Errorf("ParseDateTime(2024-07-15, 08:00) failed: %v", err)
+	}
+	if nano != winter {
+		t.Errorf("ParseDateTime(2024-07-15, 08:00) = %d; want %d", nano, winter)
+	}
+
+	if !tp.IsToday(tp.UnixNano()) {
+		t.Error("IsToday(now) should return true")
+	}
+
+	// Zone reports the name as passed, not the alias Intl resolves it to
+	for _, zone := range []string{"Asia/Kolkata", "Europe/Kyiv", "EST5EDT", "Etc/UTC"} {
+		tp, err := tinytime.NewTimeProviderInZone(zone)
+		if err != nil {
+			t.Fatalf("NewTimeProviderInZone(%s) failed: %v", zone, err)
+		}
+		if tp.Zone() != zone {
+			t.Errorf("Zone() = %s; want %s", tp.Zone(), zone)
+		}
+	}
+	kolkata, _ := tinytime.NewTimeProviderInZone("Asia/Kolkata")
+	if off := kolkata.Offset(summer); off != 19800 {
+		t.Errorf("Offset(Asia/Kolkata) = %d; want 19800", off)
+	}
+
+	t.Log("TimeProviderInZone tests passed")
+}
+
+func TestTimeProviderInZone_UTC(t *testing.T) {
+	for _, zone := range []string{"", "UTC"} {
+		tp, err := tinytime.NewTimeProviderInZone(zone)
+		if err != nil {
+			t.Fatalf("NewTimeProviderInZone(%q) failed: %v", zone, err)
+		}
+		if tp.Zone() != "UTC" {
+			t.Errorf("Zone() = %s; want UTC", tp.Zone())
+		}
+		if off := tp.Offset(GlobalTestUnixNano); off != 0 {
+			t.Errorf("Offset() = %d; want 0", off)
+		}
+	}
+
+	if off := tinytime.NewTimeProvider().Offset(GlobalTestUnixNano); off != 0 {
+		t.Errorf("NewTimeProvider().Offset() = %d; want 0", off)
+	}
+}
+
+func TestTimeProviderInZone_Invalid(t *testing.T) {
+	// Names in the wrong case and the system zone are rejected on both builds
+	for _, zone := range []string{"Mars/Olympus_Mons", "america/santiago", "AMERICA/SANTIAGO", "utc", "asia/kolkata", "Local"} {
+		tp, err := tinytime.NewTimeProviderInZone(zone)
+		if err == nil {
+			t.Errorf("NewTimeProviderInZone(%s) should return error", zone)
+		}
+		if tp != nil {
+			t.Errorf("NewTimeProviderInZone(%s) should return nil provider", zone)
+		}
+	}
+}
+
+// The last nanosecond of a second must not round up to the next one, and a wall-clock time
+// skipped by a DST gap resolves to the first instant after the gap, on both builds.
+func TestWallClockEdgesInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	nano := int64(1705307399999999999) // 2024-01-15 08:29:59.999999999 UTC
+	if got := tp.FormatDateTime(nano); got != "2024-01-15 05:29:59" {
+		t.Errorf("FormatDateTime(.999999999) = %s; want 2024-01-15 05:29:59", got)
+	}
+	if got := tinytime.NewTimeProvider().FormatTime(nano); got != "08:29:59" {
+		t.Errorf("FormatTime(.999999999) in UTC = %s; want 08:29:59", got)
+	}
+
+	// Chile enters DST at midnight on 2024-09-08: 00:00-00:59 does not exist
+	gap, err := tp.Parse("2024-09-08 00:30")
+	if err != nil {
+		t.Fatalf("Parse(2024-09-08 00:30) failed: %v", err)
+	}
+	if got := tp.FormatDateTime(gap); got != "2024-09-08 01:30:00" {
+		t.Errorf("Parse(DST gap) = %s; want 2024-09-08 01:30:00", got)
+	}
+
+	// ParseDate and ParseDateTime resolve the gap the same way, so the date round-trips
+	day, err := tp.ParseDate("2024-09-08")
+	if err != nil {
+		t.Fatalf("ParseDate(2024-09-08) failed: %v", err)
+	}
+	if got := tp.FormatDateTime(day); got != "2024-09-08 01:00:00" {
+		t.Errorf("ParseDate(DST gap day) = %s; want 2024-09-08 01:00:00", got)
+	}
+	if day != tp.StartOfDay(gap) {
+		t.Errorf("ParseDate(DST gap day) = %d; want StartOfDay %d", day, tp.StartOfDay(gap))
+	}
+	if got := tp.FormatDate(day); got != "2024-09-08" {
+		t.Errorf("FormatDate(ParseDate(2024-09-08)) = %s; want 2024-09-08", got)
+	}
+	withTime, err := tp.ParseDateTime("2024-09-08", "00:30")
+	if err != nil {
+		t.Fatalf("ParseDateTime(2024-09-08, 00:30) failed: %v", err)
+	}
+	if withTime != gap {
+		t.Errorf("ParseDateTime(DST gap) = %s; want 2024-09-08 01:30:00", tp.FormatDateTime(withTime))
+	}
+}
+
+func TestFormatInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	nano := int64(1705307400000000000) // 2024-01-15 08:30:00 UTC
+	if got := tp.Format(nano, "DD/MM/YYYY HH:mm Z"); got != "15/01/2024 05:30 -03:00" {
+		t.Errorf("Format(token) = %q; want %q", got, "15/01/2024 05:30 -03:00")
+	}
+	if got := tp.Format(nano, "2006-01-02T15:04:05-0700"); got != "2024-01-15T05:30:00-0300" {
+		t.Errorf("Format(go) = %q; want %q", got, "2024-01-15T05:30:00-0300")
+	}
+}
+
+func TestParseInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// No offset in the input: interpreted in the provider's zone
+	nano, err := tp.Parse("15/01/2024 05:30")
+	if err != nil || nano != 1705307400000000000 {
+		t.Errorf("Parse(local) = %d, %v; want 1705307400000000000", nano, err)
+	}
+
+	// Explicit offset wins over the provider's zone
+	nano, err = tp.Parse("2024-01-15T08:30:00Z")
+	if err != nil || nano != 1705307400000000000 {
+		t.Errorf("Parse(Z) = %d, %v; want 1705307400000000000", nano, err)
+	}
+}
+
+func TestAddDaysInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Chile leaves DST at midnight between 2024-04-06 and 2024-04-07: the day lasts 25 hours
+	start, err := tp.Parse("2024-04-06 12:00")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	next := tp.AddDays(start, 1)
+	if got := tp.FormatDateTimeShort(next); got != "2024-04-07 12:00" {
+		t.Errorf("AddDays across DST = %s; want 2024-04-07 12:00", got)
+	}
+	if hours := (next - start) / 3600000000000; hours != 25 {
+		t.Errorf("AddDays across DST spans %d hours; want 25", hours)
+	}
+
+	// Month arithmetic follows the zone's calendar, not UTC's: 2024-01-31 22:00 local is Feb 1 in UTC
+	end, _ := tp.Parse("2024-01-31 22:00")
+	if got := tp.FormatDateTimeShort(tp.AddMonths(end, 1)); got != "2024-02-29 22:00" {
+		t.Errorf("AddMonths in zone = %s; want 2024-02-29 22:00", got)
+	}
+}
+
+func TestPeriodBoundsInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// 2024-01-16 02:00 UTC is still 2024-01-15 23:00 in Santiago
+	nano := int64(1705370400000000000)
+	if got := tp.StartOfDay(nano); got != 1705287600000000000 { // 2024-01-15 03:00 UTC
+		t.Errorf("StartOfDay = %d; want 1705287600000000000", got)
+	}
+	if got := tp.FormatRFC3339(tp.EndOfMonth(nano), -3*3600); got != "2024-01-31T23:59:59.999999999-03:00" {
+		t.Errorf("EndOfMonth = %s; want 2024-01-31T23:59:59.999999999-03:00", got)
+	}
+
+	// Chile enters DST at midnight on 2024-09-08: 00:00 does not exist, the day starts at 01:00
+	noon, _ := tp.Parse("2024-09-08 12:00")
+	if got := tp.FormatDateTime(tp.StartOfDay(noon)); got != "2024-09-08 01:00:00" {
+		t.Errorf("StartOfDay(DST gap) = %s; want 2024-09-08 01:00:00", got)
+	}
+	if got := tp.FormatDateTime(tp.EndOfDay(tp.AddDays(noon, -1))); got != "2024-09-07 23:59:59" {
+		t.Errorf("EndOfDay(before DST gap) = %s; want 2024-09-07 23:59:59", got)
+	}
+}
+
+func TestCalendarDaysBetweenInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// 2024-01-16 02:00 UTC and 2024-01-16 04:00 UTC are different days in Santiago (23:00 and 01:00)
+	late := int64(1705370400000000000)
+	early := late + 2*3600000000000
+	if got := tp.CalendarDaysBetween(late, early); got != 1 {
+		t.Errorf("CalendarDaysBetween in zone = %d; want 1", got)
+	}
+	if got := tinytime.NewTimeProvider().CalendarDaysBetween(late, early); got != 0 {
+		t.Errorf("CalendarDaysBetween in UTC = %d; want 0", got)
+	}
+}
+
+func TestDiffComponentsInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Chile leaves DST at midnight on 2024-04-07: 40 minutes from 23:30 (UTC-3) end at 23:10 (UTC-4)
+	from := int64(1712457000000000000) // 2024-04-07 02:30 UTC
+	to := from + 40*60000000000
+	if got := tp.DiffComponents(from, to); got != (tinytime.DateDiff{Minutes: 40}) {
+		t.Errorf("DiffComponents(across DST overlap) = %+v; want {Minutes:40}", got)
+	}
+	if got := tp.DiffComponents(to, from); got != (tinytime.DateDiff{Minutes: -40}) {
+		t.Errorf("DiffComponents(reversed, across DST overlap) = %+v; want {Minutes:-40}", got)
+	}
+
+	// Longer spans keep measuring wall-clock time: 23:30 on Apr 5 to 23:10 on Apr 6
+	if got := tp.DiffComponents(from-86400000000000, to); got != (tinytime.DateDiff{Hours: 23, Minutes: 40}) {
+		t.Errorf("DiffComponents(day before, across DST overlap) = %+v; want {Hours:23 Minutes:40}", got)
+	}
+}
+
+func TestSplitByDayInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Chile leaves DST at midnight between 2024-04-06 and 2024-04-07: 23:00-24:00 repeats,
+	// so the first piece lasts 7 hours instead of 6
+	start, _ := tp.Parse("2024-04-06 18:00")
+	end, _ := tp.Parse("2024-04-08 06:00")
+	days := tinytime.Interval{Start: start, End: end}.SplitByDay(tp)
+	if len(days) != 3 {
+		t.Fatalf("SplitByDay returned %d pieces; want 3", len(days))
+	}
+	if got := tp.FormatDateTimeShort(days[1].Start); got != "2024-04-07 00:00" {
+		t.Errorf("second piece starts at %s; want 2024-04-07 00:00", got)
+	}
+	if hours := days[0].Duration() / 3600000000000; hours != 7 {
+		t.Errorf("first piece lasts %d hours; want 7", hours)
+	}
+	if hours := days[1].Duration() / 3600000000000; hours != 24 {
+		t.Errorf("second piece lasts %d hours; want 24", hours)
+	}
+}
+
+func TestFindSlotsInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Working hours are wall-clock times in the provider's zone
+	var ws tinytime.WeeklySchedule
+	ws[tinytime.Monday] = []tinytime.TimeRange{{Start: 540, End: 660}} // 09:00-11:00
+	monday, _ := tp.ParseDate("2024-01-15")
+
+	slots := tinytime.FindSlots(tp, tinytime.Interval{Start: monday, End: tp.AddDays(monday, 1)},
+		tinytime.SlotOptions{Schedule: ws, Duration: 60})
+	if len(slots) != 2 {
+		t.Fatalf("FindSlots returned %d slots; want 2", len(slots))
+	}
+	if slots[0].Start != 1705320000000000000 { // 2024-01-15 12:00 UTC
+		t.Errorf("first slot = %d; want 1705320000000000000", slots[0].Start)
+	}
+	if got := tp.FormatDateTimeShort(slots[1].Start); got != "2024-01-15 10:00" {
+		t.Errorf("second slot = %s; want 2024-01-15 10:00", got)
+	}
+}
+
+func TestRecurrenceInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Occurrences keep 09:00 local across the DST change on 2024-04-07; the date-only UNTIL
+	// includes the whole local day of 2024-04-08
+	got := expand(t, tp, "2024-04-05 09:00", "FREQ=DAILY;INTERVAL=3;UNTIL=20240408")
+	checkOccurrences(t, "in zone", got, []string{"2024-04-05 09:00", "2024-04-08 09:00"})
+
+	start, _ := tp.Parse("2024-04-05 09:00")
+	second, _ := tp.Parse("2024-04-08 09:00")
+	if hours := (second - start) / 3600000000000; hours != 73 {
+		t.Errorf("occurrences 3 days apart across DST span %d hours; want 73", hours)
+	}
+
+	// A UTC UNTIL is an instant: 2024-04-08 12:00 UTC is 08:00 in Santiago, before the occurrence
+	got = expand(t, tp, "2024-04-05 09:00", "FREQ=DAILY;INTERVAL=3;UNTIL=20240408T120000Z")
+	checkOccurrences(t, "UTC until in zone", got, []string{"2024-04-05 09:00"})
+}
+
+func TestCronInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Fields are wall-clock times in the provider's zone
+	c, _ := tinytime.ParseCron(tp, "0 9 * * *")
+	after, _ := tp.Parse("2024-01-15 08:00")
+	if got := c.Next(after); got != 1705320000000000000 { // 2024-01-15 12:00 UTC
+		t.Errorf("Next(09:00 local) = %d; want 1705320000000000000", got)
+	}
+
+	// Midnight does not exist on 2024-09-08: the run moves to the first instant after the gap
+	c, _ = tinytime.ParseCron(tp, "@daily")
+	after, _ = tp.Parse("2024-09-07 12:00")
+	if got := tp.FormatDateTime(c.Next(after)); got != "2024-09-08 01:00:00" {
+		t.Errorf("Next(@daily, DST gap) = %s; want 2024-09-08 01:00:00", got)
+	}
+
+	// 23:30 repeats on 2024-04-06: the job runs once
+	c, _ = tinytime.ParseCron(tp, "30 23 * * *")
+	after, _ = tp.Parse("2024-04-06 12:00")
+	first := c.Next(after)
+	second := c.Next(first)
+	if got := tp.FormatDateTimeShort(second); got != "2024-04-07 23:30" {
+		t.Errorf("Next after the repeated 23:30 = %s; want 2024-04-07 23:30", got)
+	}
+}
+
+func TestISOWeekInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// 2024-01-01 01:00 UTC is still Sunday 2023-12-31 in Santiago: last week of 2023, Q4
+	nano := int64(1704070800000000000)
+	if got := tp.Weekday(nano); got != tinytime.Sunday {
+		t.Errorf("Weekday in zone = %s; want Sunday", got)
+	}
+	if year, week := tp.ISOWeek(nano); year != 2023 || week != 52 {
+		t.Errorf("ISOWeek in zone = %d, %d; want 2023, 52", year, week)
+	}
+	if got := tp.Quarter(nano); got != 4 {
+		t.Errorf("Quarter in zone = %d; want 4", got)
+	}
+	if got := tp.FormatISOWeekDate(nano); got != "2023-W52-7" {
+		t.Errorf("FormatISOWeekDate in zone = %s; want 2023-W52-7", got)
+	}
+	if got := tinytime.NewTimeProvider().FormatISOWeekDate(nano); got != "2024-W01-1" {
+		t.Errorf("FormatISOWeekDate in UTC = %s; want 2024-W01-1", got)
+	}
+
+	// Chile enters DST at midnight on 2024-09-08 (Sunday of week 36): the day starts at 01:00
+	got, err := tp.ParseISOWeekDate("2024-W36-7")
+	if err != nil {
+		t.Fatalf("ParseISOWeekDate(2024-W36-7) failed: %v", err)
+	}
+	if s := tp.FormatDateTime(got); s != "2024-09-08 01:00:00" {
+		t.Errorf("ParseISOWeekDate(DST gap) = %s; want 2024-09-08 01:00:00", s)
+	}
+}
+
+func TestBusinessCalendarInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+	cal := tinytime.NewBusinessCalendar(tp, []tinytime.Weekday{tinytime.Saturday}, tinytime.FixedHoliday(9, 18))
+
+	// 2024-09-19 02:00 UTC is still 2024-09-18 23:00 (Independence Day) in Santiago
+	nano := int64(1726711200000000000)
+	if cal.IsBusinessDay(nano) {
+		t.Error("IsBusinessDay(Sep 18 in Santiago) = true; want false")
+	}
+	if utc := tinytime.NewBusinessCalendar(tinytime.NewTimeProvider(), nil, tinytime.FixedHoliday(9, 18)); !utc.IsBusinessDay(nano) {
+		t.Error("IsBusinessDay(Sep 19 in UTC) = false; want true")
+	}
+
+	// Chile enters DST at midnight on Sunday 2024-09-08: 00:30 does not exist and moves to 01:30
+	friday, _ := tp.Parse("2024-09-06 00:30")
+	if got := tp.FormatDateTimeShort(cal.AddBusinessDays(friday, 1)); got != "2024-09-08 01:30" {
+		t.Errorf("AddBusinessDays(over DST gap) = %s; want 2024-09-08 01:30", got)
+	}
+	if got := tp.FormatDateTime(cal.NextBusinessDay(friday)); got != "2024-09-08 01:00:00" {
+		t.Errorf("NextBusinessDay(over DST gap) = %s; want 2024-09-08 01:00:00", got)
+	}
+}
+
+func TestWorkingHoursInZone(t *testing.T) {
+	tp, err := tinytime.NewTimeProviderInZone("America/Santiago")
+	if err != nil {
+		t.Fatalf("NewTimeProviderInZone(America/Santiago) failed: %v", err)
+	}
+
+	// Chile leaves DST at midnight on Saturday 2024-04-06: 23:00-24:00 repeats, so a 22:00-02:00
+	// shift lasts 5 hours
+	var ws tinytime.WeeklySchedule
+	ws[tinytime.Saturday] = []tinytime.TimeRange{{Start: 1320, End: 120}}
+	wh := tinytime.WorkingHours{Schedule: ws}
+
+	from, _ := tp.ParseDate("2024-04-06")
+	to, _ := tp.ParseDate("2024-04-08")
+	if got := wh.Between(tp, from, to); got != 5*tinytime.Hour {
+		t.Errorf("Between(DST overlap) = %s; want 5h0m0s", got)
+	}
+	if got := tp.FormatDateTimeShort(wh.Deadline(tp, from, 4*60)); got != "2024-04-07 01:00" {
+		t.Errorf("Deadline(DST overlap) = %s; want 2024-04-07 01:00", got)
+	}
+
+	// Holidays close their whole local date
+	ws[tinytime.Wednesday] = []tinytime.TimeRange{{Start: 540, End: 1080}}
+	wh = tinytime.WorkingHours{Schedule: ws, Holidays: tinytime.NewBusinessCalendar(tp, nil, tinytime.FixedHoliday(9, 18))}
+	from, _ = tp.ParseDate("2024-09-18")
+	if got := wh.Between(tp, from, tp.AddDays(from, 1)); got != 0 {
+		t.Errorf("Between(holiday) = %s; want 0s", got)
+	}
+}